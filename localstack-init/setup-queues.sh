@@ -0,0 +1,13 @@
+#!/bin/bash
+
+# 送信に失敗した通知を保存するデッドレターキューを作成するスクリプト
+echo "Setting up SQS queues..."
+
+queue_name="${DEAD_LETTER_QUEUE_NAME:-schedule-reminder-dead-letters}"
+
+echo "Creating queue: $queue_name"
+awslocal sqs create-queue \
+  --queue-name "$queue_name" \
+  --attributes VisibilityTimeout=120,MessageRetentionPeriod=1209600
+
+echo "SQS setup complete!"
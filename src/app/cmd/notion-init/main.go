@@ -86,7 +86,7 @@ func parseFlags() options {
 	flag.StringVar(&opts.sampleLineRecipientID, "sample-line-recipient-id", "", "Sample LINE recipient ID")
 
 	reminderTimingOptions := flag.String("reminder-timing-options", "当日,1日前,2日前,3日前,1営業日前,2営業日前,3営業日前,4営業日前,5営業日前,1週間前,2週間前", "Comma-separated reminder timing options")
-	notificationChannels := flag.String("notification-channels", "Discord,LINE,Slack", "Comma-separated notification channels")
+	notificationChannels := flag.String("notification-channels", "Discord,LINE,Slack,Notion", "Comma-separated notification channels")
 	sampleReminderTimings := flag.String("sample-reminder-timings", "当日,1日前", "Comma-separated reminder timings for sample config")
 
 	flag.Parse()
@@ -127,6 +127,8 @@ func validateOptions(opts options) error {
 			if opts.sampleChannelToken == "" {
 				return fmt.Errorf("sample-channel-token is required for LINE sample config")
 			}
+		case "notion":
+			// Comments are posted with the integration's own API key
 		case "discord", "slack":
 			if opts.sampleWebhookURL == "" {
 				return fmt.Errorf("sample-webhook-url is required for %s sample config", opts.sampleNotification)
@@ -192,9 +194,9 @@ func masterDatabaseProperties(opts options) notionapi.PropertyConfigs {
 			Type:        notionapi.PropertyConfigTypeMultiSelect,
 			MultiSelect: notionapi.Select{Options: toOptions(opts.reminderTimingOptions)},
 		},
-	"通知チャネル": &notionapi.SelectPropertyConfig{
-		Type:   notionapi.PropertyConfigTypeSelect,
-		Select: notionapi.Select{Options: toOptions(opts.notificationChannels)},
+	"通知チャネル": &notionapi.MultiSelectPropertyConfig{
+		Type:        notionapi.PropertyConfigTypeMultiSelect,
+		MultiSelect: notionapi.Select{Options: toOptions(opts.notificationChannels)},
 	},
 	"Webhook URL": &notionapi.URLPropertyConfig{
 		Type: notionapi.PropertyConfigTypeURL,
@@ -205,6 +207,26 @@ func masterDatabaseProperties(opts options) notionapi.PropertyConfigs {
 	"LINE送信先ID": &notionapi.RichTextPropertyConfig{
 		Type: notionapi.PropertyConfigTypeRichText,
 	},
+	"メンション対象プロパティ": &notionapi.RichTextPropertyConfig{
+		Type: notionapi.PropertyConfigTypeRichText,
+	},
+	"未解決変数": &notionapi.SelectPropertyConfig{
+		Type:   notionapi.PropertyConfigTypeSelect,
+		Select: notionapi.Select{Options: toOptions([]string{"残す", "空欄", "エラー"})},
+	},
+	"言語": &notionapi.SelectPropertyConfig{
+		Type:   notionapi.PropertyConfigTypeSelect,
+		Select: notionapi.Select{Options: toOptions([]string{"日本語", "English"})},
+	},
+	"期限自動更新": &notionapi.CheckboxPropertyConfig{
+		Type: notionapi.PropertyConfigTypeCheckbox,
+	},
+	"完了時のみ更新": &notionapi.CheckboxPropertyConfig{
+		Type: notionapi.PropertyConfigTypeCheckbox,
+	},
+	"リマインド履歴を記録": &notionapi.CheckboxPropertyConfig{
+		Type: notionapi.PropertyConfigTypeCheckbox,
+	},
 	}
 }
 
@@ -233,6 +255,18 @@ func buildScheduleDatabaseRequest(opts options) *notionapi.DatabaseCreateRequest
 			"メッセージテンプレート": &notionapi.RichTextPropertyConfig{
 				Type: notionapi.PropertyConfigTypeRichText,
 			},
+			"繰り返し": &notionapi.RichTextPropertyConfig{
+				Type: notionapi.PropertyConfigTypeRichText,
+			},
+			"完了": &notionapi.CheckboxPropertyConfig{
+				Type: notionapi.PropertyConfigTypeCheckbox,
+			},
+			"最終リマインド日時": &notionapi.DatePropertyConfig{
+				Type: notionapi.PropertyConfigTypeDate,
+			},
+			"リマインド履歴": &notionapi.RichTextPropertyConfig{
+				Type: notionapi.PropertyConfigTypeRichText,
+			},
 		},
 	}
 }
@@ -252,9 +286,9 @@ func buildSampleConfigProperties(opts options, scheduleDBID string) notionapi.Pr
 			Type:        notionapi.PropertyTypeMultiSelect,
 			MultiSelect: toOptions(opts.sampleReminderTimings),
 		},
-		"通知チャネル": &notionapi.SelectProperty{
-			Type:   notionapi.PropertyTypeSelect,
-			Select: notionapi.Option{Name: opts.sampleNotification},
+		"通知チャネル": &notionapi.MultiSelectProperty{
+			Type:        notionapi.PropertyTypeMultiSelect,
+			MultiSelect: []notionapi.Option{{Name: opts.sampleNotification}},
 		},
 	"Webhook URL": &notionapi.URLProperty{
 		Type: notionapi.PropertyTypeURL,
@@ -0,0 +1,300 @@
+// Command reminder runs the reminder pipeline once from a terminal, without
+// SAM or LocalStack, to debug configurations against the real Notion workspace.
+//
+//	reminder [flags]           process today's reminders
+//	reminder upcoming [flags]  list the reminders of the coming days
+//	reminder calendar [flags]  export due dates and reminders as an iCalendar feed
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"schedule-reminder/internal/domain/service"
+	awsinfra "schedule-reminder/internal/infrastructure/aws"
+	"schedule-reminder/internal/infrastructure/ical"
+	"schedule-reminder/internal/infrastructure/logging"
+	"schedule-reminder/internal/infrastructure/notion"
+)
+
+// calendarName is the name calendar apps show for the feed
+const calendarName = "Notionリマインダー"
+
+type options struct {
+	apiKey     string
+	masterDBID string
+	useSSM     bool
+	date       string
+	dryRun     bool
+	configIDs  []string
+	output     string
+	debug      bool
+	upcoming   bool
+	calendar   bool
+	days       int
+	outFile    string
+}
+
+func main() {
+	incomplete, err := run(os.Args[1:])
+	if err != nil {
+		log.Fatal(err)
+	}
+	if incomplete {
+		os.Exit(1)
+	}
+}
+
+// run executes the command, reporting whether anything failed or could not be
+// evaluated. It returns instead of exiting so that deferred cleanup runs.
+func run(args []string) (incomplete bool, err error) {
+	opts := parseFlags(args)
+	if err := validateOptions(opts); err != nil {
+		return false, fmt.Errorf("invalid options: %w", err)
+	}
+
+	level := logging.LevelFromEnv()
+	if opts.debug {
+		level = slog.LevelDebug
+	}
+	// Logs go to stderr so that the report on stdout can be piped
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := resolveSecrets(ctx, &opts); err != nil {
+		return false, err
+	}
+
+	reminderOpts, err := serviceOptions(opts)
+	if err != nil {
+		return false, fmt.Errorf("invalid options: %w", err)
+	}
+	reminderService := service.NewReminderService(notion.NewClient(opts.apiKey), opts.masterDBID, reminderOpts...)
+
+	switch {
+	case opts.upcoming:
+		return runUpcoming(ctx, reminderService, opts)
+	case opts.calendar:
+		return runCalendar(ctx, reminderService, opts)
+	default:
+		return runReminders(ctx, reminderService, opts)
+	}
+}
+
+// runReminders processes today's reminders and writes the run report
+func runReminders(ctx context.Context, reminderService *service.ReminderService, opts options) (bool, error) {
+	report, err := reminderService.ProcessReminders(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to process reminders: %w", err)
+	}
+
+	if opts.output == "json" {
+		err = writeJSON(os.Stdout, report)
+	} else {
+		err = writeTable(os.Stdout, report)
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to write report: %w", err)
+	}
+	return report.Failures() > 0, nil
+}
+
+// runUpcoming lists the reminders of the coming days
+func runUpcoming(ctx context.Context, reminderService *service.ReminderService, opts options) (bool, error) {
+	report, err := reminderService.Upcoming(ctx, opts.days)
+	if err != nil {
+		return false, fmt.Errorf("failed to list upcoming reminders: %w", err)
+	}
+
+	switch opts.output {
+	case "json":
+		err = writeJSON(os.Stdout, report)
+	case "csv":
+		err = writeUpcomingCSV(os.Stdout, report)
+	default:
+		err = writeUpcomingTable(os.Stdout, report)
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to write report: %w", err)
+	}
+	return len(report.Issues) > 0, nil
+}
+
+// runCalendar writes the iCalendar feed of the coming days to a file or stdout.
+// The feed is encoded in full first, so a failure never leaves a partial file.
+func runCalendar(ctx context.Context, reminderService *service.ReminderService, opts options) (bool, error) {
+	feed, err := reminderService.CalendarEvents(ctx, opts.days)
+	if err != nil {
+		return false, fmt.Errorf("failed to build calendar: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := ical.Encode(&buf, calendarName, feed.Events, time.Now()); err != nil {
+		return false, fmt.Errorf("failed to encode calendar: %w", err)
+	}
+	if opts.outFile == "-" {
+		_, err = os.Stdout.Write(buf.Bytes())
+	} else {
+		err = os.WriteFile(opts.outFile, buf.Bytes(), 0o644)
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to write calendar: %w", err)
+	}
+
+	for _, issue := range feed.Issues {
+		slog.Warn("incomplete calendar", "config", issue.Config, "schedule", issue.Schedule, logging.KeyError, issue.Error)
+	}
+	slog.Info("wrote calendar", "events", len(feed.Events), "file", opts.outFile)
+	return len(feed.Issues) > 0, nil
+}
+
+func parseFlags(args []string) options {
+	var opts options
+
+	name := "reminder"
+	if len(args) > 0 {
+		switch args[0] {
+		case "upcoming":
+			opts.upcoming = true
+			name, args = "reminder upcoming", args[1:]
+		case "calendar":
+			opts.calendar = true
+			name, args = "reminder calendar", args[1:]
+		}
+	}
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+
+	fs.StringVar(&opts.apiKey, "api-key", "", "Notion API key (or NOTION_API_KEY)")
+	fs.StringVar(&opts.masterDBID, "master-db", "", "Reminder config master database ID (or REMINDER_CONFIG_DB_ID)")
+	fs.BoolVar(&opts.useSSM, "ssm", false, "Read the API key and master database ID missing from flags and env from Parameter Store")
+	fs.StringVar(&opts.date, "date", "", "Process as if today were this date (YYYY-MM-DD)")
+	fs.BoolVar(&opts.debug, "debug", false, "Output debug logs (or DEBUG=1)")
+	configIDs := fs.String("config", "", "Comma-separated config page IDs to process (default: all enabled configs)")
+	switch {
+	case opts.upcoming:
+		fs.IntVar(&opts.days, "days", 14, "Number of days to list, starting today")
+		fs.StringVar(&opts.output, "output", "table", "Report format: json, csv or table")
+	case opts.calendar:
+		fs.IntVar(&opts.days, "days", 90, "Number of days to export, starting today")
+		fs.StringVar(&opts.outFile, "out", "-", "File to write the .ics feed to (- for stdout)")
+	default:
+		fs.BoolVar(&opts.dryRun, "dry-run", false, "Render notifications without sending them or writing to Notion")
+		fs.StringVar(&opts.output, "output", "table", "Report format: json or table")
+	}
+
+	fs.Parse(args)
+
+	if opts.apiKey == "" {
+		opts.apiKey = firstEnvValue("NOTION_API_KEY", "NOTION_TOKEN")
+	}
+	if opts.masterDBID == "" {
+		opts.masterDBID = firstEnvValue("REMINDER_CONFIG_DB_ID")
+	}
+	opts.configIDs = splitList(*configIDs)
+
+	return opts
+}
+
+func validateOptions(opts options) error {
+	if !opts.useSSM {
+		if opts.apiKey == "" {
+			return fmt.Errorf("api-key is required (or use --ssm)")
+		}
+		if opts.masterDBID == "" {
+			return fmt.Errorf("master-db is required (or use --ssm)")
+		}
+	}
+	if opts.date != "" {
+		if _, err := time.Parse("2006-01-02", opts.date); err != nil {
+			return fmt.Errorf("date must be YYYY-MM-DD: %q", opts.date)
+		}
+	}
+	switch {
+	case opts.calendar, opts.output == "json", opts.output == "table", opts.output == "csv" && opts.upcoming:
+	case opts.upcoming:
+		return fmt.Errorf("output must be json, csv or table: %q", opts.output)
+	default:
+		return fmt.Errorf("output must be json or table: %q", opts.output)
+	}
+	if (opts.upcoming || opts.calendar) && (opts.days < 1 || opts.days > service.MaxUpcomingDays) {
+		return fmt.Errorf("days must be between 1 and %d: %d", service.MaxUpcomingDays, opts.days)
+	}
+	return nil
+}
+
+// resolveSecrets fills the API key and master database ID from Parameter Store
+// when --ssm is set, using the same parameter names as the Lambda function
+func resolveSecrets(ctx context.Context, opts *options) error {
+	if !opts.useSSM || (opts.apiKey != "" && opts.masterDBID != "") {
+		return nil
+	}
+
+	ssmClient, err := awsinfra.NewSSMClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create SSM client: %w", err)
+	}
+	if opts.apiKey == "" {
+		if opts.apiKey, err = ssmClient.GetParameter(ctx, "NOTION_API_KEY"); err != nil {
+			return fmt.Errorf("failed to get NOTION_API_KEY: %w", err)
+		}
+	}
+	if opts.masterDBID == "" {
+		if opts.masterDBID, err = ssmClient.GetParameter(ctx, "REMINDER_CONFIG_DB_ID"); err != nil {
+			return fmt.Errorf("failed to get REMINDER_CONFIG_DB_ID: %w", err)
+		}
+	}
+	return nil
+}
+
+// serviceOptions turns the flags into service options. Unlike the Lambda
+// function, the CLI never uses the dead-letter queue, operator alerts, metrics
+// or the catch-up state.
+func serviceOptions(opts options) ([]service.Option, error) {
+	var reminderOpts []service.Option
+	if opts.dryRun {
+		reminderOpts = append(reminderOpts, service.WithDryRun())
+	}
+	if opts.date != "" {
+		date, err := time.Parse("2006-01-02", opts.date)
+		if err != nil {
+			return nil, err
+		}
+		reminderOpts = append(reminderOpts, service.WithDate(date))
+	}
+	if len(opts.configIDs) > 0 {
+		reminderOpts = append(reminderOpts, service.WithScope(opts.configIDs, nil))
+	}
+	return reminderOpts, nil
+}
+
+func splitList(value string) []string {
+	parts := strings.Split(value, ",")
+	out := make([]string, 0, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		out = append(out, part)
+	}
+	return out
+}
+
+func firstEnvValue(keys ...string) string {
+	for _, key := range keys {
+		if value := strings.TrimSpace(os.Getenv(key)); value != "" {
+			return value
+		}
+	}
+	return ""
+}
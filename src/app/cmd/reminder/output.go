@@ -0,0 +1,114 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"schedule-reminder/internal/domain/service"
+)
+
+// writeJSON writes a report as the Lambda function returns it
+func writeJSON(w io.Writer, report any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+// writeTable writes one row per configuration, followed by the failures and,
+// in a dry run, the rendered notifications
+func writeTable(w io.Writer, report *service.RunReport) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "CONFIG\tSCHEDULES\tSENT\tFAILED\tSKIPPED\tERROR")
+	for _, config := range report.InvalidConfigs {
+		fmt.Fprintf(tw, "%s\t-\t-\t-\t-\t%s\n", config.Name, config.Error)
+	}
+	for _, config := range report.Configs {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\t%s\n", config.Name, config.Schedules,
+			config.NotificationsSent, config.NotificationsFailed, config.NotificationsSkipped, config.Error)
+	}
+	fmt.Fprintf(tw, "TOTAL\t%d\t%d\t%d\t%d\t\n", report.SchedulesEvaluated,
+		report.NotificationsSent, report.NotificationsFailed, report.NotificationsSkipped)
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	var failures, previews bool
+	for _, config := range report.Configs {
+		failures = failures || len(config.Failures) > 0
+		previews = previews || len(config.Previews) > 0
+	}
+
+	if failures {
+		fmt.Fprintln(w)
+		tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "CONFIG\tSCHEDULE\tTIMING\tCHANNEL\tERROR")
+		for _, config := range report.Configs {
+			for _, f := range config.Failures {
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", config.Name, f.Schedule, f.Timing, f.Channel, f.Error)
+			}
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if previews {
+		fmt.Fprintln(w)
+		tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "CONFIG\tSCHEDULE\tDUE\tTIMING\tCHANNEL\tMESSAGE")
+		for _, config := range report.Configs {
+			for _, p := range config.Previews {
+				// Multi-line messages would break the columns
+				message := strings.ReplaceAll(p.Message, "\n", " ⏎ ")
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", config.Name, p.Schedule, p.DueDate, p.Timing, p.Channel, message)
+			}
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if report.DryRun {
+		fmt.Fprintln(w, "\n(dry run: nothing was sent)")
+	}
+	return nil
+}
+
+// writeUpcomingTable writes one row per upcoming reminder, followed by the issues
+func writeUpcomingTable(w io.Writer, report *service.UpcomingReport) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "DATE\tCONFIG\tSCHEDULE\tDUE\tTIMING\tCHANNEL")
+	for _, r := range report.Reminders {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", r.Date, r.Config, r.Schedule, r.DueDate, r.Timing, r.Channel)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "\n%d reminder(s) in %d day(s) from %s\n", len(report.Reminders), report.Days, report.From)
+
+	if len(report.Issues) > 0 {
+		fmt.Fprintln(w)
+		tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "CONFIG\tSCHEDULE\tERROR")
+		for _, issue := range report.Issues {
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", issue.Config, issue.Schedule, issue.Error)
+		}
+		return tw.Flush()
+	}
+	return nil
+}
+
+// writeUpcomingCSV writes the upcoming reminders with a header row; issues are left out
+func writeUpcomingCSV(w io.Writer, report *service.UpcomingReport) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"date", "config_id", "config", "schedule_id", "schedule", "url", "due_date", "timing", "channel"})
+	for _, r := range report.Reminders {
+		cw.Write([]string{r.Date, r.ConfigID, r.Config, r.ScheduleID, r.Schedule, r.URL, r.DueDate, r.Timing, r.Channel})
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"schedule-reminder/internal/domain/service"
+)
+
+func TestWriteTable(t *testing.T) {
+	report := &service.RunReport{
+		SchedulesEvaluated: 3,
+		NotificationsSent:  1,
+		DryRun:             true,
+		InvalidConfigs:     []service.ConfigReport{{Name: "未設定", Error: "validation error"}},
+		Configs: []service.ConfigReport{
+			{
+				Name:              "週次",
+				Schedules:         3,
+				NotificationsSent: 1,
+				Previews: []service.PreviewReport{
+					{Schedule: "定例会", DueDate: "2026-10-20", Timing: "1日前", Channel: "Slack", Message: "【リマインド】定例会\n明日"},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := writeTable(&buf, report); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"未設定", "validation error", "TOTAL", "【リマインド】定例会 ⏎ 明日", "dry run"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output does not contain %q:\n%s", want, out)
+		}
+	}
+	// Only the preview table lists timings; there were no failures
+	if n := strings.Count(out, "TIMING"); n != 1 {
+		t.Errorf("got %d tables with timings, want 1:\n%s", n, out)
+	}
+}
+
+func TestWriteUpcomingCSV(t *testing.T) {
+	report := &service.UpcomingReport{
+		Reminders: []service.UpcomingReminder{
+			{Date: "2026-10-19", ConfigID: "c1", Config: "週次", ScheduleID: "p1", Schedule: "定例会, 第3回", DueDate: "2026-10-20", Timing: "1日前", Channel: "Slack"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := writeUpcomingCSV(&buf, report); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "date,config_id,config,schedule_id,schedule,url,due_date,timing,channel\n" +
+		"2026-10-19,c1,週次,p1,\"定例会, 第3回\",,2026-10-20,1日前,Slack\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
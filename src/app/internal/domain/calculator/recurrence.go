@@ -0,0 +1,429 @@
+package calculator
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency represents how often a recurring schedule repeats
+type Frequency string
+
+const (
+	FrequencyDaily   Frequency = "DAILY"
+	FrequencyWeekly  Frequency = "WEEKLY"
+	FrequencyMonthly Frequency = "MONTHLY"
+	FrequencyYearly  Frequency = "YEARLY"
+)
+
+// maxRecurrenceIterations guards against runaway expansion of malformed rules
+const maxRecurrenceIterations = 10000
+
+// WeekdayNum is a weekday optionally qualified by its position in the month.
+// N == 0 means every such weekday, N > 0 the Nth one, N < 0 counts from the end.
+type WeekdayNum struct {
+	Weekday time.Weekday
+	N       int
+}
+
+// Recurrence is a parsed RRULE-like recurrence rule
+type Recurrence struct {
+	Frequency  Frequency
+	Interval   int
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	Count      int
+	Until      time.Time
+}
+
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+var japaneseWeekdays = map[string]time.Weekday{
+	"日": time.Sunday,
+	"月": time.Monday,
+	"火": time.Tuesday,
+	"水": time.Wednesday,
+	"木": time.Thursday,
+	"金": time.Friday,
+	"土": time.Saturday,
+}
+
+// ParseRecurrence parses a recurrence rule
+// Supported formats:
+// - RRULE syntax: "FREQ=MONTHLY;BYDAY=2TU", "RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=MO"
+// - "毎日" -> every day
+// - "平日" -> every weekday (Mon-Fri)
+// - "毎週月曜", "毎週月・木曜" -> every week on the given weekdays
+// - "隔週金曜" -> every other week
+// - "毎月15日", "毎月末" -> every month on the given day
+// - "毎月第2火曜", "毎月最終金曜" -> every month on the Nth weekday
+// - "毎年" -> every year on the due date
+func ParseRecurrence(rule string) (*Recurrence, error) {
+	rule = strings.TrimSpace(rule)
+	if rule == "" {
+		return nil, fmt.Errorf("empty recurrence rule")
+	}
+
+	var (
+		rec *Recurrence
+		err error
+	)
+	if strings.Contains(strings.ToUpper(rule), "FREQ=") {
+		rec, err = parseRRule(rule)
+	} else {
+		rec, err = parseJapaneseRecurrence(rule)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if rec.Interval <= 0 {
+		rec.Interval = 1
+	}
+	return rec, nil
+}
+
+func parseRRule(rule string) (*Recurrence, error) {
+	rule = strings.TrimPrefix(strings.ToUpper(rule), "RRULE:")
+	rec := &Recurrence{}
+
+	for _, part := range strings.Split(rule, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid recurrence rule part: %s", part)
+		}
+
+		switch key {
+		case "FREQ":
+			switch Frequency(value) {
+			case FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyYearly:
+				rec.Frequency = Frequency(value)
+			default:
+				return nil, fmt.Errorf("unsupported recurrence frequency: %s", value)
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("invalid recurrence interval: %s", value)
+			}
+			rec.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("invalid recurrence count: %s", value)
+			}
+			rec.Count = n
+		case "UNTIL":
+			until, err := parseRRuleDate(value)
+			if err != nil {
+				return nil, err
+			}
+			rec.Until = until
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				wn, err := parseRRuleWeekday(day)
+				if err != nil {
+					return nil, err
+				}
+				rec.ByDay = append(rec.ByDay, wn)
+			}
+		case "BYMONTHDAY":
+			for _, day := range strings.Split(value, ",") {
+				n, err := strconv.Atoi(strings.TrimSpace(day))
+				if err != nil || n == 0 || n < -31 || n > 31 {
+					return nil, fmt.Errorf("invalid recurrence month day: %s", day)
+				}
+				rec.ByMonthDay = append(rec.ByMonthDay, n)
+			}
+		case "WKST":
+			// Weeks always start on Monday
+		default:
+			return nil, fmt.Errorf("unsupported recurrence rule part: %s", key)
+		}
+	}
+
+	if rec.Frequency == "" {
+		return nil, fmt.Errorf("recurrence rule requires FREQ: %s", rule)
+	}
+	return rec, nil
+}
+
+func parseRRuleDate(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405", "20060102", "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid recurrence until date: %s", value)
+}
+
+func parseRRuleWeekday(value string) (WeekdayNum, error) {
+	value = strings.TrimSpace(value)
+	if len(value) < 2 {
+		return WeekdayNum{}, fmt.Errorf("invalid recurrence weekday: %s", value)
+	}
+
+	weekday, ok := rruleWeekdays[value[len(value)-2:]]
+	if !ok {
+		return WeekdayNum{}, fmt.Errorf("invalid recurrence weekday: %s", value)
+	}
+
+	wn := WeekdayNum{Weekday: weekday}
+	if prefix := value[:len(value)-2]; prefix != "" {
+		n, err := strconv.Atoi(prefix)
+		if err != nil || n == 0 || n < -5 || n > 5 {
+			return WeekdayNum{}, fmt.Errorf("invalid recurrence weekday: %s", value)
+		}
+		wn.N = n
+	}
+	return wn, nil
+}
+
+var (
+	jpWeeklyPattern       = regexp.MustCompile(`^(毎週|隔週)([日月火水木金土・、,]+)曜?日?$`)
+	jpMonthDayPattern     = regexp.MustCompile(`^毎月(\d+)日$`)
+	jpMonthWeekdayPattern = regexp.MustCompile(`^(?:毎月)?(?:第(\d)|(最終))([日月火水木金土])曜?日?$`)
+)
+
+func parseJapaneseRecurrence(rule string) (*Recurrence, error) {
+	switch rule {
+	case "毎日":
+		return &Recurrence{Frequency: FrequencyDaily}, nil
+	case "平日":
+		rec := &Recurrence{Frequency: FrequencyWeekly}
+		for d := time.Monday; d <= time.Friday; d++ {
+			rec.ByDay = append(rec.ByDay, WeekdayNum{Weekday: d})
+		}
+		return rec, nil
+	case "毎週":
+		return &Recurrence{Frequency: FrequencyWeekly}, nil
+	case "隔週":
+		return &Recurrence{Frequency: FrequencyWeekly, Interval: 2}, nil
+	case "毎月":
+		return &Recurrence{Frequency: FrequencyMonthly}, nil
+	case "毎月末", "毎月末日":
+		return &Recurrence{Frequency: FrequencyMonthly, ByMonthDay: []int{-1}}, nil
+	case "毎年":
+		return &Recurrence{Frequency: FrequencyYearly}, nil
+	}
+
+	if match := jpWeeklyPattern.FindStringSubmatch(rule); len(match) == 3 {
+		rec := &Recurrence{Frequency: FrequencyWeekly}
+		if match[1] == "隔週" {
+			rec.Interval = 2
+		}
+		for _, r := range match[2] {
+			if weekday, ok := japaneseWeekdays[string(r)]; ok {
+				rec.ByDay = append(rec.ByDay, WeekdayNum{Weekday: weekday})
+			}
+		}
+		if len(rec.ByDay) == 0 {
+			return nil, fmt.Errorf("unsupported recurrence format: %s", rule)
+		}
+		return rec, nil
+	}
+
+	if match := jpMonthDayPattern.FindStringSubmatch(rule); len(match) == 2 {
+		day, err := strconv.Atoi(match[1])
+		if err != nil || day < 1 || day > 31 {
+			return nil, fmt.Errorf("invalid day in recurrence: %s", rule)
+		}
+		return &Recurrence{Frequency: FrequencyMonthly, ByMonthDay: []int{day}}, nil
+	}
+
+	if match := jpMonthWeekdayPattern.FindStringSubmatch(rule); len(match) == 4 {
+		n := -1
+		if match[2] == "" {
+			var err error
+			n, err = strconv.Atoi(match[1])
+			if err != nil || n < 1 || n > 5 {
+				return nil, fmt.Errorf("invalid week number in recurrence: %s", rule)
+			}
+		}
+		return &Recurrence{
+			Frequency: FrequencyMonthly,
+			ByDay:     []WeekdayNum{{Weekday: japaneseWeekdays[match[3]], N: n}},
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported recurrence format: %s", rule)
+}
+
+// Occurrences expands the rule starting at anchor and returns every occurrence
+// whose date falls within [from, to] (inclusive, ignoring time of day).
+// Occurrences keep the anchor's time of day and location.
+func (r *Recurrence) Occurrences(anchor, from, to time.Time) []time.Time {
+	loc := anchor.Location()
+	anchorDay := truncateToDate(anchor)
+	fromDay := truncateToDate(from.In(loc))
+	toDay := truncateToDate(to.In(loc))
+
+	var (
+		occurrences []time.Time
+		count       int
+	)
+
+	for i := 0; i < maxRecurrenceIterations; i++ {
+		candidates := r.periodCandidates(anchorDay, i)
+		if len(candidates) == 0 && r.periodStart(anchorDay, i).After(toDay) {
+			break
+		}
+
+		for _, day := range candidates {
+			if day.Before(anchorDay) {
+				continue
+			}
+			if day.After(toDay) {
+				return occurrences
+			}
+			if !r.Until.IsZero() && day.After(truncateToDate(r.Until.In(loc))) {
+				return occurrences
+			}
+			count++
+			if r.Count > 0 && count > r.Count {
+				return occurrences
+			}
+			if !day.Before(fromDay) {
+				occurrences = append(occurrences, time.Date(day.Year(), day.Month(), day.Day(),
+					anchor.Hour(), anchor.Minute(), anchor.Second(), anchor.Nanosecond(), loc))
+			}
+		}
+	}
+
+	return occurrences
+}
+
+// Next returns the first occurrence whose date is on or after from
+func (r *Recurrence) Next(anchor, from time.Time) (time.Time, bool) {
+	// One more period than the interval is always enough to find the next occurrence
+	occurrences := r.Occurrences(anchor, from, from.AddDate(r.Interval+1, 0, 0))
+	if len(occurrences) == 0 {
+		return time.Time{}, false
+	}
+	return occurrences[0], true
+}
+
+// periodStart returns the first day of the i-th period after the anchor's period
+func (r *Recurrence) periodStart(anchorDay time.Time, i int) time.Time {
+	step := i * r.Interval
+	switch r.Frequency {
+	case FrequencyWeekly:
+		return weekStart(anchorDay).AddDate(0, 0, 7*step)
+	case FrequencyMonthly:
+		return time.Date(anchorDay.Year(), anchorDay.Month()+time.Month(step), 1, 0, 0, 0, 0, anchorDay.Location())
+	case FrequencyYearly:
+		return time.Date(anchorDay.Year()+step, 1, 1, 0, 0, 0, 0, anchorDay.Location())
+	default:
+		return anchorDay.AddDate(0, 0, step)
+	}
+}
+
+// periodCandidates returns the sorted candidate days within the i-th period
+func (r *Recurrence) periodCandidates(anchorDay time.Time, i int) []time.Time {
+	start := r.periodStart(anchorDay, i)
+	loc := anchorDay.Location()
+
+	var days []time.Time
+	switch r.Frequency {
+	case FrequencyDaily:
+		days = append(days, start)
+
+	case FrequencyWeekly:
+		if len(r.ByDay) == 0 {
+			days = append(days, start.AddDate(0, 0, weekdayOffset(anchorDay.Weekday())))
+			break
+		}
+		for _, wd := range r.ByDay {
+			days = append(days, start.AddDate(0, 0, weekdayOffset(wd.Weekday)))
+		}
+
+	case FrequencyMonthly:
+		days = monthCandidates(start.Year(), start.Month(), anchorDay.Day(), r.ByDay, r.ByMonthDay, loc)
+
+	case FrequencyYearly:
+		days = monthCandidates(start.Year(), anchorDay.Month(), anchorDay.Day(), r.ByDay, r.ByMonthDay, loc)
+	}
+
+	sort.Slice(days, func(a, b int) bool { return days[a].Before(days[b]) })
+	return dedupeDays(days)
+}
+
+func monthCandidates(year int, month time.Month, anchorDay int, byDay []WeekdayNum, byMonthDay []int, loc *time.Location) []time.Time {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, loc)
+	lastDay := first.AddDate(0, 1, -1).Day()
+
+	var days []time.Time
+	for _, md := range byMonthDay {
+		day := md
+		if md < 0 {
+			day = lastDay + md + 1
+		}
+		if day >= 1 && day <= lastDay {
+			days = append(days, time.Date(year, month, day, 0, 0, 0, 0, loc))
+		}
+	}
+
+	for _, wd := range byDay {
+		var matches []time.Time
+		for d := 1; d <= lastDay; d++ {
+			date := time.Date(year, month, d, 0, 0, 0, 0, loc)
+			if date.Weekday() == wd.Weekday {
+				matches = append(matches, date)
+			}
+		}
+		switch {
+		case wd.N == 0:
+			days = append(days, matches...)
+		case wd.N > 0 && wd.N <= len(matches):
+			days = append(days, matches[wd.N-1])
+		case wd.N < 0 && -wd.N <= len(matches):
+			days = append(days, matches[len(matches)+wd.N])
+		}
+	}
+
+	if len(byMonthDay) == 0 && len(byDay) == 0 && anchorDay <= lastDay {
+		// Months without the anchor day are skipped, as in RFC 5545
+		days = append(days, time.Date(year, month, anchorDay, 0, 0, 0, 0, loc))
+	}
+
+	return days
+}
+
+func dedupeDays(days []time.Time) []time.Time {
+	out := days[:0]
+	for i, day := range days {
+		if i > 0 && day.Equal(days[i-1]) {
+			continue
+		}
+		out = append(out, day)
+	}
+	return out
+}
+
+// weekdayOffset returns the number of days from Monday to the given weekday
+func weekdayOffset(weekday time.Weekday) int {
+	return (int(weekday) + 6) % 7
+}
+
+func weekStart(day time.Time) time.Time {
+	return day.AddDate(0, 0, -weekdayOffset(day.Weekday()))
+}
+
+func truncateToDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
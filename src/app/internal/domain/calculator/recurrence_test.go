@@ -0,0 +1,139 @@
+package calculator
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRecurrence(t *testing.T) {
+	tests := []struct {
+		rule        string
+		want        Frequency
+		expectError bool
+	}{
+		{"FREQ=MONTHLY;BYDAY=2TU", FrequencyMonthly, false},
+		{"RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=MO", FrequencyWeekly, false},
+		{"毎日", FrequencyDaily, false},
+		{"毎週月曜", FrequencyWeekly, false},
+		{"毎月第2火曜", FrequencyMonthly, false},
+		{"毎月末", FrequencyMonthly, false},
+		{"毎年", FrequencyYearly, false},
+		{"FREQ=HOURLY", "", true},
+		{"たまに", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseRecurrence(tt.rule)
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("expected error for %q", tt.rule)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("rule %q: unexpected error: %v", tt.rule, err)
+		}
+		if got.Frequency != tt.want {
+			t.Fatalf("rule %q: got frequency %s, want %s", tt.rule, got.Frequency, tt.want)
+		}
+	}
+}
+
+func TestRecurrenceOccurrences(t *testing.T) {
+	loc := time.FixedZone("JST", 9*3600)
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, loc)
+	}
+
+	tests := []struct {
+		name   string
+		rule   string
+		anchor time.Time
+		from   time.Time
+		to     time.Time
+		want   []time.Time
+	}{
+		{
+			name:   "second tuesday of the month",
+			rule:   "毎月第2火曜",
+			anchor: date(2024, 1, 9),
+			from:   date(2024, 3, 1),
+			to:     date(2024, 4, 30),
+			want:   []time.Time{date(2024, 3, 12), date(2024, 4, 9)},
+		},
+		{
+			name:   "biweekly monday",
+			rule:   "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO",
+			anchor: date(2024, 1, 8),
+			from:   date(2024, 1, 9),
+			to:     date(2024, 2, 6),
+			want:   []time.Time{date(2024, 1, 22), date(2024, 2, 5)},
+		},
+		{
+			name:   "end of month",
+			rule:   "毎月末",
+			anchor: date(2024, 1, 31),
+			from:   date(2024, 2, 1),
+			to:     date(2024, 3, 31),
+			want:   []time.Time{date(2024, 2, 29), date(2024, 3, 31)},
+		},
+		{
+			name:   "monthly on the 31st skips short months",
+			rule:   "FREQ=MONTHLY",
+			anchor: date(2024, 1, 31),
+			from:   date(2024, 2, 1),
+			to:     date(2024, 4, 30),
+			want:   []time.Time{date(2024, 3, 31)},
+		},
+		{
+			name:   "count limits occurrences",
+			rule:   "FREQ=DAILY;COUNT=3",
+			anchor: date(2024, 1, 1),
+			from:   date(2024, 1, 2),
+			to:     date(2024, 1, 10),
+			want:   []time.Time{date(2024, 1, 2), date(2024, 1, 3)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, err := ParseRecurrence(tt.rule)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := rec.Occurrences(tt.anchor, tt.from, tt.to)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Fatalf("occurrence %d: got %s, want %s", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRecurrenceNext(t *testing.T) {
+	loc := time.FixedZone("JST", 9*3600)
+	anchor := time.Date(2024, 1, 9, 10, 0, 0, 0, loc)
+
+	rec, err := ParseRecurrence("毎月第2火曜")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, ok := rec.Next(anchor, time.Date(2024, 1, 10, 0, 0, 0, 0, loc))
+	want := time.Date(2024, 2, 13, 10, 0, 0, 0, loc)
+	if !ok || !got.Equal(want) {
+		t.Fatalf("got %s (ok=%v), want %s", got, ok, want)
+	}
+
+	rec, err = ParseRecurrence("FREQ=WEEKLY;UNTIL=20240115")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, ok := rec.Next(anchor, time.Date(2024, 1, 20, 0, 0, 0, 0, loc)); ok {
+		t.Fatalf("expected no occurrence after UNTIL, got %s", got)
+	}
+}
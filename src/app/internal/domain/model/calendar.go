@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// CalendarEvent is one due date of a schedule in a calendar feed
+type CalendarEvent struct {
+	UID         string // The page ID, plus the date for an occurrence of a recurring schedule, so calendar apps move a rescheduled event instead of duplicating it
+	Summary     string
+	Description string
+	URL         string
+	Start       time.Time
+	AllDay      bool // Start is a date in Start's location
+	Alarms      []CalendarAlarm
+}
+
+// CalendarAlarm is a computed reminder date of a CalendarEvent
+type CalendarAlarm struct {
+	Trigger     time.Time
+	Description string
+}
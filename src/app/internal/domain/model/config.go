@@ -1,21 +1,80 @@
 package model
 
-import "time"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Policies for template placeholders that match no variable or property
+const (
+	PlaceholderLeave = "leave" // Keep the raw "{placeholder}" in the message
+	PlaceholderBlank = "blank" // Replace it with an empty string
+	PlaceholderFail  = "fail"  // Fail the notification
+)
+
+// Built-in message languages
+const (
+	LanguageJapanese = "ja"
+	LanguageEnglish  = "en"
+)
+
+// notificationChannels lists the supported channel names (lowercase)
+var notificationChannels = []string{"discord", "line", "slack", "notion"}
+
+// IsNotificationChannel reports whether name is a supported channel, ignoring case
+func IsNotificationChannel(name string) bool {
+	for _, channel := range notificationChannels {
+		if strings.EqualFold(channel, name) {
+			return true
+		}
+	}
+	return false
+}
 
 // ReminderConfig represents configuration loaded from the parent Notion database
 type ReminderConfig struct {
-	ID                  string
-	Name                string
-	TargetDatabaseID    string
-	ReminderTimings     []string
-	NotificationChannel string
-	WebhookURL          string
-	ChannelToken        string
-	LineRecipientID     string
-	MessageTemplate     string
-	DatePropertyName    string
-	TitlePropertyName   string
-	Timezone            *time.Location
+	ID                       string
+	Name                     string
+	URL                      string // Notion page of the configuration
+	TargetDatabaseID         string
+	ReminderTimings          []string
+	NotificationChannels     []string
+	WebhookURL               string
+	ChannelToken             string
+	LineRecipientID          string
+	MentionPropertyName      string // People property whose members are @mentioned by the Notion channel
+	MessageTemplate          string
+	TimingTemplates          map[string]string // Templates keyed by the timing that fired
+	ChannelTemplates         map[string]string // Templates keyed by lowercase channel name
+	UnresolvedPlaceholders   string            // One of the Placeholder* policies
+	Language                 string            // Message language code, e.g. "ja" or "en"
+	DatePropertyName         string
+	TitlePropertyName        string
+	RecurrencePropertyName   string
+	CompletionPropertyName   string
+	AutoAdvanceDueDate       bool // Write the next occurrence back once a recurring due date passes
+	AdvanceOnlyWhenDone      bool // Only auto-advance when the completion checkbox is ticked
+	RecordHistory            bool // Write the last reminder time and history back to the schedule page
+	LastRemindedPropertyName string
+	HistoryPropertyName      string
+	Timezone                 *time.Location
+}
+
+// ConfigLoadError describes a row of the master database that could not be loaded
+type ConfigLoadError struct {
+	PageID string
+	Name   string
+	URL    string
+	Err    error
+}
+
+func (e *ConfigLoadError) Error() string {
+	return fmt.Sprintf("config %s (%s): %v", e.Name, e.PageID, e.Err)
+}
+
+func (e *ConfigLoadError) Unwrap() error {
+	return e.Err
 }
 
 // Validate checks if the configuration is valid
@@ -26,8 +85,23 @@ func (c *ReminderConfig) Validate() error {
 	if len(c.ReminderTimings) == 0 {
 		return &ValidationError{Field: "ReminderTimings", Message: "at least one timing required"}
 	}
-	if c.NotificationChannel == "" {
-		return &ValidationError{Field: "NotificationChannel", Message: "required"}
+	if len(c.NotificationChannels) == 0 {
+		return &ValidationError{Field: "NotificationChannels", Message: "at least one channel required"}
+	}
+	if c.hasChannel("slack") && c.hasChannel("discord") {
+		// Both read WebhookURL, so one of them would post the wrong payload to the other's webhook
+		return &ValidationError{Field: "NotificationChannels", Message: "Slack and Discord share the webhook URL and cannot be combined; use one config per channel"}
+	}
+	switch c.UnresolvedPlaceholders {
+	case "":
+		c.UnresolvedPlaceholders = PlaceholderLeave
+	case PlaceholderLeave, PlaceholderBlank, PlaceholderFail:
+	default:
+		return &ValidationError{Field: "UnresolvedPlaceholders", Message: "must be leave, blank or fail"}
+	}
+	c.Language = strings.ToLower(strings.TrimSpace(c.Language))
+	if c.Language == "" {
+		c.Language = LanguageJapanese
 	}
 	if c.DatePropertyName == "" {
 		c.DatePropertyName = "期限日" // Fixed
@@ -35,8 +109,30 @@ func (c *ReminderConfig) Validate() error {
 	if c.TitlePropertyName == "" {
 		c.TitlePropertyName = "タイトル" // Fixed
 	}
+	if c.RecurrencePropertyName == "" {
+		c.RecurrencePropertyName = "繰り返し" // Fixed
+	}
+	if c.CompletionPropertyName == "" {
+		c.CompletionPropertyName = "完了" // Fixed
+	}
+	if c.LastRemindedPropertyName == "" {
+		c.LastRemindedPropertyName = "最終リマインド日時" // Fixed
+	}
+	if c.HistoryPropertyName == "" {
+		c.HistoryPropertyName = "リマインド履歴" // Fixed
+	}
 	if c.Timezone == nil {
 		c.Timezone = time.FixedZone("JST", 9*3600) // Fixed to JST
 	}
 	return nil
 }
+
+// hasChannel reports whether the configuration notifies name, ignoring case
+func (c *ReminderConfig) hasChannel(name string) bool {
+	for _, channel := range c.NotificationChannels {
+		if strings.EqualFold(strings.TrimSpace(channel), name) {
+			return true
+		}
+	}
+	return false
+}
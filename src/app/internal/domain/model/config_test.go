@@ -0,0 +1,36 @@
+package model
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateNotificationChannels(t *testing.T) {
+	tests := []struct {
+		channels []string
+		wantErr  bool
+	}{
+		{[]string{"Slack"}, false},
+		{[]string{"Discord", "LINE", "Notion"}, false},
+		{[]string{"Slack", "LINE"}, false},
+		{[]string{"Slack", "Discord"}, true},
+		{[]string{"discord", " SLACK "}, true},
+		{nil, true},
+	}
+	for _, tt := range tests {
+		config := &ReminderConfig{
+			TargetDatabaseID:     "db",
+			ReminderTimings:      []string{"当日"},
+			NotificationChannels: tt.channels,
+			WebhookURL:           "https://hooks.slack.com/services/x",
+		}
+		err := config.Validate()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%v: got error %v, want error %v", tt.channels, err, tt.wantErr)
+		}
+		var validationErr *ValidationError
+		if err != nil && !errors.As(err, &validationErr) {
+			t.Errorf("%v: expected a ValidationError, got %T", tt.channels, err)
+		}
+	}
+}
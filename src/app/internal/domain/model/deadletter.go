@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// DeadLetter is a rendered notification that could not be delivered after all
+// retries, kept so that it can be replayed once the destination is fixed
+type DeadLetter struct {
+	ConfigID      string              `json:"configId"`
+	ConfigName    string              `json:"configName"`
+	ScheduleID    string              `json:"scheduleId"`
+	ScheduleTitle string              `json:"scheduleTitle"`
+	NotionURL     string              `json:"notionUrl,omitempty"`
+	PeopleIDs     map[string][]string `json:"peopleIds,omitempty"` // Needed to @mention on the Notion channel
+	DueDate       time.Time           `json:"dueDate"`
+	Timing        string              `json:"timing"`
+	Channel       string              `json:"channel"`
+	Message       string              `json:"message"`
+	Destination   string              `json:"destination"`
+	Error         string              `json:"error"`
+	StatusCode    int                 `json:"statusCode,omitempty"`
+	SentChunks    int                 `json:"sentChunks,omitempty"` // Chunks delivered before the failure; a redrive sends the rest
+	FailedAt      time.Time           `json:"failedAt"`
+	Attempts      int                 `json:"attempts,omitempty"` // Failed redrives so far
+
+	// ReceiptHandle identifies a received message for deletion; it is not stored
+	ReceiptHandle string `json:"-"`
+}
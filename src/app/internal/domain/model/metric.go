@@ -0,0 +1,15 @@
+package model
+
+// Metric units understood by CloudWatch
+const (
+	UnitCount        = "Count"
+	UnitMilliseconds = "Milliseconds"
+)
+
+// Metric is one measurement of a run, such as a sent notification or the latency of a call
+type Metric struct {
+	Name       string
+	Unit       string
+	Value      float64
+	Dimensions map[string]string
+}
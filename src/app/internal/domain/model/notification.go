@@ -2,9 +2,11 @@ package model
 
 // Notification represents a notification to be sent
 type Notification struct {
-	Schedule   *Schedule
-	Config     *ReminderConfig
-	Timing     string
-	Message    string
+	Schedule    *Schedule
+	Config      *ReminderConfig
+	Timing      string
+	Channel     string
+	Message     string
 	Destination string
+	SentChunks  int // Leading chunks of a split message already delivered; sending resumes after them
 }
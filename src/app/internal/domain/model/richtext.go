@@ -0,0 +1,26 @@
+package model
+
+import "strings"
+
+// TextSpan is a run of text sharing the same formatting
+type TextSpan struct {
+	Text          string
+	Bold          bool
+	Italic        bool
+	Strikethrough bool
+	Underline     bool
+	Code          bool
+	Link          string
+}
+
+// RichText is formatted text made of consecutive spans
+type RichText []TextSpan
+
+// PlainText concatenates all spans without formatting
+func (r RichText) PlainText() string {
+	var builder strings.Builder
+	for _, span := range r {
+		builder.WriteString(span.Text)
+	}
+	return builder.String()
+}
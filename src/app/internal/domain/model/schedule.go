@@ -7,11 +7,24 @@ type Schedule struct {
 	ID              string
 	Title           string
 	DueDate         time.Time
+	AllDay          bool // DueDate has no time of day
 	Description     string
 	MessageTemplate string
+	TimingTemplates map[string]string // Templates keyed by the timing that fired
 	ReminderTimings []string
+	Recurrence      string // RRULE-like rule; DueDate is the first occurrence
+	Completed       bool
 	NotionURL       string
 	Properties      map[string]interface{} // All properties for template rendering
+	RichText        map[string]RichText    // Formatted title and rich text properties
+	PeopleIDs       map[string][]string    // User IDs per People property, for mentions
+}
+
+// SentReminder is a delivered reminder to record on its schedule page
+type SentReminder struct {
+	Timing  string
+	Channel string
+	SentAt  time.Time
 }
 
 // Validate checks if the schedule is valid
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"schedule-reminder/internal/domain/model"
+	"schedule-reminder/internal/infrastructure/logging"
+	"schedule-reminder/internal/infrastructure/notifier"
+	"strings"
+	"time"
+)
+
+// operatorAlertTimeout bounds sending the operator alert, which happens after the run's work
+const operatorAlertTimeout = 10 * time.Second
+
+// WithOperatorAlerts sends a consolidated error report to n after every run with failures
+func WithOperatorAlerts(n notifier.Notifier) Option {
+	return func(s *ReminderService) {
+		s.operatorAlerts = n
+	}
+}
+
+// alertOperators sends the failures of a run to the operator alert destination, if configured
+func (s *ReminderService) alertOperators(ctx context.Context, report *RunReport) {
+	if s.operatorAlerts == nil || s.dryRun || !report.HasFailures() {
+		return
+	}
+
+	notification := &model.Notification{
+		Channel: s.operatorAlerts.Type(),
+		Message: FormatOperatorAlert(report),
+	}
+
+	// The run may be out of time, but the report is most useful exactly then
+	alertCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), operatorAlertTimeout)
+	defer cancel()
+	if err := s.retry.send(alertCtx, s.operatorAlerts, notification); err != nil {
+		logging.FromContext(ctx).Error("failed to send operator alert", logging.KeyError, err)
+		return
+	}
+	logging.FromContext(ctx).Info("sent operator alert", "notifier", s.operatorAlerts.Type())
+}
+
+// FormatOperatorAlert renders every failure of a run, grouped by configuration,
+// with the Notion page to fix and the cause
+func FormatOperatorAlert(report *RunReport) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "⚠️ Schedule Reminder: %d failure(s) in this run\n", report.Failures())
+	fmt.Fprintf(&b, "Sent %d, failed %d, skipped %d notification(s)\n",
+		report.NotificationsSent, report.NotificationsFailed, report.NotificationsSkipped)
+
+	if report.RunStateError != "" {
+		fmt.Fprintf(&b, "\n■ Run dates not saved; the next run may send reminders again\n• %s\n", report.RunStateError)
+	}
+
+	if len(report.InvalidConfigs) > 0 {
+		b.WriteString("\n■ Configs that could not be loaded\n")
+		for _, config := range report.InvalidConfigs {
+			fmt.Fprintf(&b, "• %s%s: %s\n", displayName(config.Name, config.ID), linkSuffix(config.URL), config.Error)
+		}
+	}
+
+	for _, config := range report.Configs {
+		if config.Error == "" && len(config.Failures) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&b, "\n■ %s%s\n", displayName(config.Name, config.ID), linkSuffix(config.URL))
+		if config.Error != "" {
+			fmt.Fprintf(&b, "• %s\n", config.Error)
+		}
+		for _, failure := range config.Failures {
+			var details []string
+			for _, part := range []string{failure.Timing, failure.Channel} {
+				if part != "" {
+					details = append(details, part)
+				}
+			}
+			label := failure.Schedule
+			if len(details) > 0 {
+				label += " [" + strings.Join(details, " / ") + "]"
+			}
+			fmt.Fprintf(&b, "• %s%s: %s\n", label, linkSuffix(failure.URL), failure.Error)
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func displayName(name, id string) string {
+	if name != "" {
+		return name
+	}
+	return id
+}
+
+func linkSuffix(url string) string {
+	if url == "" {
+		return ""
+	}
+	return " (" + url + ")"
+}
@@ -0,0 +1,41 @@
+package service
+
+import "testing"
+
+func TestFormatOperatorAlert(t *testing.T) {
+	report := &RunReport{
+		ConfigsFailed:       1,
+		NotificationsSent:   4,
+		NotificationsFailed: 1,
+		InvalidConfigs: []ConfigReport{
+			{ID: "p1", Name: "月次", URL: "https://www.notion.so/p1", Error: "validation error: TargetDatabaseID: required"},
+		},
+		Configs: []ConfigReport{
+			{ID: "p2", Name: "問題なし", NotificationsSent: 4},
+			{
+				ID:   "p3",
+				Name: "週次",
+				URL:  "https://www.notion.so/p3",
+				Failures: []FailureReport{
+					{Schedule: "定例会", URL: "https://www.notion.so/s1", Error: `invalid reminder timing "3日後"`},
+					{Schedule: "定例会", URL: "https://www.notion.so/s1", Timing: "1日前", Channel: "Slack", Error: "slack webhook returned status 404"},
+				},
+			},
+			{ID: "p4", Name: "", Error: "failed to fetch schedules: 404"},
+		},
+	}
+
+	want := "⚠️ Schedule Reminder: 3 failure(s) in this run\n" +
+		"Sent 4, failed 1, skipped 0 notification(s)\n" +
+		"\n■ Configs that could not be loaded\n" +
+		"• 月次 (https://www.notion.so/p1): validation error: TargetDatabaseID: required\n" +
+		"\n■ 週次 (https://www.notion.so/p3)\n" +
+		"• 定例会 (https://www.notion.so/s1): invalid reminder timing \"3日後\"\n" +
+		"• 定例会 [1日前 / Slack] (https://www.notion.so/s1): slack webhook returned status 404\n" +
+		"\n■ p4\n" +
+		"• failed to fetch schedules: 404"
+
+	if got := FormatOperatorAlert(report); got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"schedule-reminder/internal/domain/calculator"
+	"schedule-reminder/internal/domain/model"
+	"schedule-reminder/internal/infrastructure/logging"
+	"schedule-reminder/internal/infrastructure/tracing"
+	"sort"
+	"strings"
+	"time"
+)
+
+// calendarAlarmHour is the local hour at which calendar alarms ring, matching the daily run
+const calendarAlarmHour = 9
+
+// CalendarFeed holds the events of a calendar export
+type CalendarFeed struct {
+	Events []model.CalendarEvent
+	Issues []FailureReport
+}
+
+// CalendarEvents returns one event per schedule due in the coming days, or
+// reminded of in them, with an alarm on each of those days a reminder fires,
+// evaluated exactly as the runs on those days would. Scope overrides apply.
+func (s *ReminderService) CalendarEvents(ctx context.Context, days int) (feed *CalendarFeed, err error) {
+	ctx, span := tracing.Start(ctx, "CalendarEvents")
+	defer func() { tracing.End(span, err) }()
+
+	if days < 1 || days > MaxUpcomingDays {
+		return nil, fmt.Errorf("days must be between 1 and %d: %d", MaxUpcomingDays, days)
+	}
+
+	configs, loadErrors, err := s.notionClient.LoadReminderConfigs(ctx, s.masterDBID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configurations: %w", err)
+	}
+	configs, loadErrors = s.scopeConfigs(configs, loadErrors)
+
+	feed = &CalendarFeed{}
+	for _, loadErr := range loadErrors {
+		feed.Issues = append(feed.Issues, FailureReport{
+			Config: displayName(loadErr.Name, loadErr.PageID),
+			URL:    loadErr.URL,
+			Error:  loadErr.Err.Error(),
+		})
+	}
+
+	results := make([]*CalendarFeed, len(configs))
+	runBounded(len(configs), configConcurrency, func(i int) {
+		results[i] = s.calendarForConfig(ctx, configs[i], days)
+	})
+	for _, result := range results {
+		feed.Events = append(feed.Events, result.Events...)
+		feed.Issues = append(feed.Issues, result.Issues...)
+	}
+
+	sort.SliceStable(feed.Events, func(i, j int) bool {
+		return feed.Events[i].Start.Before(feed.Events[j].Start)
+	})
+
+	logging.FromContext(ctx).Info("built calendar events", "days", days, "events", len(feed.Events), "issues", len(feed.Issues))
+	return feed, nil
+}
+
+// calendarForConfig builds the events of one configuration
+func (s *ReminderService) calendarForConfig(ctx context.Context, config *model.ReminderConfig, days int) *CalendarFeed {
+	result := &CalendarFeed{}
+	ctx, _ = logging.With(ctx, logging.KeyConfigID, config.ID, logging.KeyConfigName, config.Name)
+
+	today := s.today(config)
+
+	schedules, err := s.notionClient.FetchSchedules(ctx, config, today)
+	if err != nil {
+		result.Issues = append(result.Issues, FailureReport{
+			Config: config.Name,
+			URL:    config.URL,
+			Error:  fmt.Errorf("failed to fetch schedules: %w", err).Error(),
+		})
+		return result
+	}
+	schedules = s.scopeSchedules(schedules)
+
+	calc := calculator.NewBusinessDayCalculator(loadHolidays(ctx, config.Timezone), config.Timezone)
+
+	window := daysFrom(today, days)
+	last := window[len(window)-1]
+	for _, schedule := range schedules {
+		occurrences, due, issues := s.dueReminders(schedule, config, window, calc)
+		for _, issue := range issues {
+			result.Issues = append(result.Issues, issue.report(config.Name))
+		}
+		for _, occurrence := range occurrences {
+			// Occurrences of a recurring schedule are copies, told apart by date
+			event := calendarEvent(occurrence, config, due, occurrence != schedule)
+
+			// A later due date is still shown when it is reminded of within the window
+			dueDate := occurrence.DueDate.In(config.Timezone)
+			if dueDate.After(last) && !calculator.IsSameDate(dueDate, last) && len(event.Alarms) == 0 {
+				continue
+			}
+			result.Events = append(result.Events, event)
+		}
+	}
+	return result
+}
+
+// calendarEvent converts one occurrence into an event, with an alarm on each
+// day of due that reminds of it. The UID is the page ID, with the date added
+// for an occurrence of a recurring schedule.
+func calendarEvent(schedule *model.Schedule, config *model.ReminderConfig, due []dueReminder, occurrence bool) model.CalendarEvent {
+	timings := config.ReminderTimings
+	if len(schedule.ReminderTimings) > 0 {
+		timings = schedule.ReminderTimings
+	}
+
+	uid := strings.ReplaceAll(schedule.ID, "-", "")
+	if occurrence {
+		uid += "-" + schedule.DueDate.In(config.Timezone).Format("20060102")
+	}
+	event := model.CalendarEvent{
+		UID:         uid + "@schedule-reminder",
+		Summary:     schedule.Title,
+		Description: fmt.Sprintf("%s\n%s: %s", schedule.Description, config.Name, strings.Join(timings, ", ")),
+		URL:         schedule.NotionURL,
+		Start:       schedule.DueDate,
+		AllDay:      schedule.AllDay,
+	}
+	if schedule.Description == "" {
+		event.Description = strings.TrimPrefix(event.Description, "\n")
+	}
+	if !event.AllDay {
+		event.Start = schedule.DueDate.In(config.Timezone)
+	}
+
+	// Days are in order and each appears once per occurrence
+	for _, reminder := range due {
+		if reminder.Occurrence != schedule {
+			continue
+		}
+		day := reminder.Day
+		event.Alarms = append(event.Alarms, model.CalendarAlarm{
+			Trigger:     time.Date(day.Year(), day.Month(), day.Day(), calendarAlarmHour, 0, 0, 0, config.Timezone),
+			Description: fmt.Sprintf("%s (%s)", schedule.Title, strings.Join(reminder.Timings, ", ")),
+		})
+	}
+	return event
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"schedule-reminder/internal/domain/model"
+	"testing"
+	"time"
+)
+
+func TestCalendarEvents(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	now := time.Date(2026, 3, 9, 9, 0, 0, 0, jst) // Monday
+	notion := &fakeNotion{
+		configs: []*model.ReminderConfig{
+			{
+				ID:              "config-1",
+				Name:            "週次",
+				ReminderTimings: []string{"当日", "1営業日前", "1日前"},
+				Timezone:        jst,
+			},
+		},
+		schedules: map[string][]*model.Schedule{
+			"config-1": {
+				{ID: "page-1", Title: "定例会", DueDate: time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC), AllDay: true},
+				{ID: "page-2", Title: "来月の予定", DueDate: time.Date(2026, 4, 16, 0, 0, 0, 0, time.UTC), AllDay: true},
+				{ID: "page-3", Title: "週次", DueDate: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), AllDay: true, Recurrence: "FREQ=WEEKLY", ReminderTimings: []string{"当日"}},
+			},
+		},
+	}
+	s := NewReminderService(notion, "master", WithClock(func() time.Time { return now }))
+
+	feed, err := s.CalendarEvents(context.Background(), 14)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(feed.Events) != 3 || len(feed.Issues) != 0 {
+		t.Fatalf("got events %+v and issues %+v", feed.Events, feed.Issues)
+	}
+
+	// A one-off schedule keeps its UID when its due date moves; occurrences are told apart by date
+	var uids []string
+	var event model.CalendarEvent
+	for _, e := range feed.Events {
+		uids = append(uids, e.UID)
+		if e.Summary == "定例会" {
+			event = e
+		}
+	}
+	if want := []string{"page3-20260309@schedule-reminder", "page1@schedule-reminder", "page3-20260316@schedule-reminder"}; !reflect.DeepEqual(uids, want) {
+		t.Errorf("got UIDs %v, want %v", uids, want)
+	}
+	if !event.AllDay {
+		t.Errorf("unexpected event %+v", event)
+	}
+	// 1日前 is Sunday and 1営業日前 is Friday; both ring at 09:00 local time
+	want := []time.Time{
+		time.Date(2026, 3, 13, 9, 0, 0, 0, jst),
+		time.Date(2026, 3, 15, 9, 0, 0, 0, jst),
+		time.Date(2026, 3, 16, 9, 0, 0, 0, jst),
+	}
+	if len(event.Alarms) != len(want) {
+		t.Fatalf("got alarms %+v", event.Alarms)
+	}
+	for i, alarm := range event.Alarms {
+		if !alarm.Trigger.Equal(want[i]) {
+			t.Errorf("alarm %d: got %v, want %v", i, alarm.Trigger, want[i])
+		}
+	}
+}
+
+func TestCalendarEventsIncludeLaterDueDatesRemindedInWindow(t *testing.T) {
+	now := time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC)
+	notion := &fakeNotion{
+		configs: []*model.ReminderConfig{
+			{ID: "config-1", Name: "週次", ReminderTimings: []string{"7日前"}, NotificationChannels: []string{"Slack"}, Timezone: time.UTC},
+		},
+		schedules: map[string][]*model.Schedule{
+			"config-1": {
+				// Due three days after the 14-day window, reminded within it
+				{ID: "page-1", Title: "申請締切", DueDate: time.Date(2026, 3, 25, 0, 0, 0, 0, time.UTC), AllDay: true},
+				// Reminded after the window as well
+				{ID: "page-2", Title: "来月の予定", DueDate: time.Date(2026, 4, 16, 0, 0, 0, 0, time.UTC), AllDay: true},
+			},
+		},
+	}
+	s := NewReminderService(notion, "master", WithClock(func() time.Time { return now }))
+
+	feed, err := s.CalendarEvents(context.Background(), 14)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(feed.Events) != 1 || feed.Events[0].Summary != "申請締切" {
+		t.Fatalf("got events %+v", feed.Events)
+	}
+	alarms := feed.Events[0].Alarms
+	if want := time.Date(2026, 3, 18, 9, 0, 0, 0, time.UTC); len(alarms) != 1 || !alarms[0].Trigger.Equal(want) {
+		t.Errorf("got alarms %+v, want one at %v", alarms, want)
+	}
+
+	// Upcoming lists the same reminder
+	report, err := s.Upcoming(context.Background(), 14)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.Reminders) != 1 || report.Reminders[0].Date != "2026-03-18" {
+		t.Errorf("got upcoming reminders %+v", report.Reminders)
+	}
+}
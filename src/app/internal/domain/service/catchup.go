@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"schedule-reminder/internal/domain/calculator"
+	"schedule-reminder/internal/domain/model"
+	"schedule-reminder/internal/infrastructure/logging"
+	"time"
+)
+
+// RunStateStore persists the date each configuration was last processed, so
+// that the run after an outage can catch up on the days in between
+type RunStateStore interface {
+	LastRunDates(ctx context.Context) (map[string]string, error) // "2006-01-02" keyed by config ID
+	SaveLastRunDate(ctx context.Context, configID, date string) error
+	DeleteLastRunDate(ctx context.Context, configID string) error
+}
+
+// WithCatchUp sends reminders whose date fell between a configuration's last
+// run and today, up to maxDays days back. They are sent once, marked as late.
+func WithCatchUp(store RunStateStore, maxDays int) Option {
+	return func(s *ReminderService) {
+		s.runState = store
+		s.catchUpDays = maxDays
+	}
+}
+
+// catchUpEnabled reports whether this run reads and records run dates.
+// Runs for another date, for single schedules, to another channel or without
+// sending do not stand for a regular run.
+func (s *ReminderService) catchUpEnabled() bool {
+	return s.runState != nil && !s.dryRun && s.date.IsZero() && len(s.scheduleScope) == 0 && s.channelOverride == ""
+}
+
+// loadLastRunDates returns the recorded run dates, or nil when catch-up is off or the state cannot be read
+func (s *ReminderService) loadLastRunDates(ctx context.Context) map[string]string {
+	if !s.catchUpEnabled() {
+		return nil
+	}
+	dates, err := s.runState.LastRunDates(ctx)
+	if err != nil {
+		logging.FromContext(ctx).Warn("failed to load last run dates, not catching up", logging.KeyError, err)
+		return nil
+	}
+	return dates
+}
+
+// saveLastRunDates records today as the last run date of every configuration
+// that was processed. Dates of configurations that are no longer enabled are
+// dropped, so re-enabling one does not send a backlog.
+// An error means the next run may send some reminders again, late.
+func (s *ReminderService) saveLastRunDates(ctx context.Context, previous map[string]string, configs []*model.ReminderConfig, results []*ConfigResult) error {
+	if previous == nil {
+		return nil
+	}
+
+	var errs []error
+	enabled := make(map[string]bool, len(configs))
+	for i, config := range configs {
+		enabled[config.ID] = true
+		// A failed config keeps its date, to catch up on its days next time
+		if results[i].Err != nil {
+			continue
+		}
+		date := s.today(config).Format("2006-01-02")
+		if previous[config.ID] == date {
+			continue
+		}
+		if err := s.runState.SaveLastRunDate(ctx, config.ID, date); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(s.configScope) == 0 {
+		// With a config scope, the others were not part of this run
+		for id := range previous {
+			if enabled[id] {
+				continue
+			}
+			if err := s.runState.DeleteLastRunDate(ctx, id); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		logging.FromContext(ctx).Error("failed to save last run dates", logging.KeyError, err)
+		return fmt.Errorf("failed to save last run dates: %w", err)
+	}
+	return nil
+}
+
+// missedDays returns the days after lastRun and before today, oldest first,
+// limited to the maxDays days before today. A missing or unparsable lastRun
+// means there is nothing to catch up on.
+func missedDays(lastRun string, today time.Time, maxDays int) []time.Time {
+	if lastRun == "" || maxDays <= 0 {
+		return nil
+	}
+	last, err := time.ParseInLocation("2006-01-02", lastRun, today.Location())
+	if err != nil {
+		return nil
+	}
+
+	var days []time.Time
+	for n := maxDays; n >= 1; n-- {
+		day := today.AddDate(0, 0, -n)
+		if day.After(last) && !calculator.IsSameDate(day, last) {
+			days = append(days, day)
+		}
+	}
+	return days
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"schedule-reminder/internal/domain/model"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memoryRunState keeps run dates in memory
+type memoryRunState struct {
+	dates   map[string]string
+	saveErr error // Returned by every save
+}
+
+func (m *memoryRunState) LastRunDates(ctx context.Context) (map[string]string, error) {
+	dates := make(map[string]string, len(m.dates))
+	for id, date := range m.dates {
+		dates[id] = date
+	}
+	return dates, nil
+}
+
+func (m *memoryRunState) SaveLastRunDate(ctx context.Context, configID, date string) error {
+	if m.saveErr != nil {
+		return m.saveErr
+	}
+	m.dates[configID] = date
+	return nil
+}
+
+func (m *memoryRunState) DeleteLastRunDate(ctx context.Context, configID string) error {
+	delete(m.dates, configID)
+	return nil
+}
+
+func TestMissedDays(t *testing.T) {
+	today := time.Date(2026, 3, 12, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		lastRun string
+		maxDays int
+		want    []string
+	}{
+		{"", 3, nil},
+		{"2026-03-11", 3, nil},
+		{"2026-03-12", 3, nil},
+		{"2026-03-09", 3, []string{"2026-03-10", "2026-03-11"}},
+		{"2026-03-01", 3, []string{"2026-03-09", "2026-03-10", "2026-03-11"}},
+		{"2026-03-09", 0, nil},
+		{"not a date", 3, nil},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, day := range missedDays(tt.lastRun, today, tt.maxDays) {
+			got = append(got, day.Format("2006-01-02"))
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("missedDays(%q, %d) = %v, want %v", tt.lastRun, tt.maxDays, got, tt.want)
+		}
+	}
+}
+
+func TestProcessRemindersCatchesUp(t *testing.T) {
+	var mu sync.Mutex
+	var messages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		defer mu.Unlock()
+		messages = append(messages, string(body))
+	}))
+	defer server.Close()
+
+	now := time.Date(2026, 3, 12, 9, 0, 0, 0, time.UTC)
+	notion := &fakeNotion{
+		configs: []*model.ReminderConfig{
+			{
+				ID:                   "config-1",
+				Name:                 "週次",
+				ReminderTimings:      []string{"1日前"},
+				NotificationChannels: []string{"Slack"},
+				WebhookURL:           server.URL,
+				MessageTemplate:      "{title}",
+				Language:             model.LanguageJapanese,
+				Timezone:             time.UTC,
+			},
+			{ID: "config-2", Name: "壊れた設定", Timezone: time.UTC},
+		},
+		schedules: map[string][]*model.Schedule{
+			"config-1": {
+				// Reminded on the 10th, which was missed
+				{ID: "page-1", Title: "定例会", DueDate: time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC)},
+				// Reminded today
+				{ID: "page-2", Title: "請求書送付", DueDate: time.Date(2026, 3, 13, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+	}
+	store := &memoryRunState{dates: map[string]string{"config-1": "2026-03-09", "config-2": "2026-03-09", "removed": "2026-03-01"}}
+	s := NewReminderService(notion, "master",
+		WithClock(func() time.Time { return now }),
+		WithCatchUp(store, 3))
+
+	report, err := s.ProcessReminders(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.NotificationsSent != 2 {
+		t.Fatalf("got %d notifications sent, want 2", report.NotificationsSent)
+	}
+
+	var late int
+	for _, m := range messages {
+		if m == `{"text":"【遅延】定例会"}` {
+			late++
+		}
+	}
+	if late != 1 {
+		t.Errorf("expected one late reminder, got %v", messages)
+	}
+
+	// The failed config keeps its date; removed configs are dropped
+	want := map[string]string{"config-1": "2026-03-12", "config-2": "2026-03-09"}
+	if !reflect.DeepEqual(store.dates, want) {
+		t.Errorf("got run dates %v, want %v", store.dates, want)
+	}
+}
+
+func TestProcessRemindersReportsUnsavedRunDates(t *testing.T) {
+	now := time.Date(2026, 3, 12, 9, 0, 0, 0, time.UTC)
+	notion := &fakeNotion{
+		configs:   []*model.ReminderConfig{{ID: "config-1", Name: "週次", Timezone: time.UTC}},
+		schedules: map[string][]*model.Schedule{"config-1": {}},
+	}
+	store := &memoryRunState{dates: map[string]string{"config-1": "2026-03-11"}, saveErr: errors.New("ParameterLimitExceeded")}
+	s := NewReminderService(notion, "master",
+		WithClock(func() time.Time { return now }),
+		WithCatchUp(store, 3))
+
+	report, err := s.ProcessReminders(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(report.RunStateError, "ParameterLimitExceeded") {
+		t.Fatalf("got run state error %q", report.RunStateError)
+	}
+	if !report.HasFailures() || report.Failures() != 1 {
+		t.Errorf("an unsaved run date should count as a failure: %+v", report)
+	}
+	if alert := FormatOperatorAlert(report); !strings.Contains(alert, "ParameterLimitExceeded") {
+		t.Errorf("operator alert does not mention the error:\n%s", alert)
+	}
+}
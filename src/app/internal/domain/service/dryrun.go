@@ -0,0 +1,24 @@
+package service
+
+import "net/url"
+
+// WithDryRun evaluates and renders every notification without sending it.
+// Nothing is written back to Notion, dead-lettered or alerted; the rendered
+// notifications are logged and returned as previews in the run report.
+func WithDryRun() Option {
+	return func(s *ReminderService) {
+		s.dryRun = true
+	}
+}
+
+// maskDestination hides the secret part of a destination so that it can be logged.
+// Webhook URLs keep only their scheme and host; IDs keep their first and last characters.
+func maskDestination(destination string) string {
+	if u, err := url.Parse(destination); err == nil && u.Scheme != "" && u.Host != "" {
+		return u.Scheme + "://" + u.Host + "/***"
+	}
+	if len(destination) <= 8 {
+		return "***"
+	}
+	return destination[:4] + "***" + destination[len(destination)-4:]
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"schedule-reminder/internal/domain/model"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMaskDestination(t *testing.T) {
+	tests := []struct {
+		destination string
+		want        string
+	}{
+		{"https://hooks.slack.com/services/T000/B000/secret", "https://hooks.slack.com/***"},
+		{"https://discord.com/api/webhooks/123/token", "https://discord.com/***"},
+		{"U1234567890abcdef1234567890abcdef", "U123***cdef"},
+		{"short", "***"},
+		{"", "***"},
+	}
+
+	for _, tt := range tests {
+		if got := maskDestination(tt.destination); got != tt.want {
+			t.Errorf("maskDestination(%q) = %q, want %q", tt.destination, got, tt.want)
+		}
+	}
+}
+
+func TestProcessRemindersDryRun(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+	}))
+	defer server.Close()
+
+	tz := time.UTC
+	now := time.Date(2026, 3, 9, 9, 0, 0, 0, tz) // A Monday
+	tomorrow := now.AddDate(0, 0, 1)
+	notion := &fakeNotion{
+		configs: []*model.ReminderConfig{{
+			ID:                   "config-1",
+			Name:                 "週次",
+			ReminderTimings:      []string{"1日前"},
+			NotificationChannels: []string{"Slack"},
+			WebhookURL:           server.URL + "/services/secret",
+			MessageTemplate:      "{title} {days_text}",
+			Language:             model.LanguageJapanese,
+			Timezone:             tz,
+		}},
+		schedules: map[string][]*model.Schedule{
+			"config-1": {{ID: "page-1", Title: "定例会", DueDate: tomorrow}},
+		},
+	}
+	sink := &recordingSink{}
+	s := NewReminderService(notion, "master", WithDryRun(), WithMetrics(sink), WithClock(func() time.Time { return now }))
+
+	report, err := s.ProcessReminders(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 0 {
+		t.Fatalf("dry run sent %d requests", n)
+	}
+	if !report.DryRun || len(report.Configs) != 1 || len(report.Configs[0].Previews) != 1 {
+		t.Fatalf("expected one preview in a dry-run report, got %+v", report)
+	}
+
+	preview := report.Configs[0].Previews[0]
+	if preview.Message != "定例会 明日" || preview.Channel != "Slack" || preview.Timing != "1日前" {
+		t.Errorf("unexpected preview %+v", preview)
+	}
+	if preview.Destination != maskDestination(server.URL) {
+		t.Errorf("destination not masked: %q", preview.Destination)
+	}
+	if got := sink.sum(MetricNotificationsSent, map[string]string{DimensionConfig: "週次", DimensionChannel: "slack"}); got != 0 {
+		t.Errorf("dry run recorded %v sent notifications", got)
+	}
+}
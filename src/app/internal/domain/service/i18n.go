@@ -0,0 +1,115 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"schedule-reminder/internal/domain/calculator"
+	"schedule-reminder/internal/domain/model"
+	"strconv"
+	"time"
+)
+
+// Catalog holds the language-dependent parts of a notification message
+type Catalog struct {
+	DefaultTemplate string
+	DateFormat      string              // Layout for {due_date}
+	Weekdays        [7]string           // Indexed by time.Weekday, used for {weekday}
+	DaysText        func(string) string // Relative-day phrasing for a timing, used for {days_text}
+	OverdueText     func(int) string    // Phrasing for a due date that passed n days ago, used by late reminders
+	Late            string              // Marker of a late reminder, used for {late}
+}
+
+// catalogs are the built-in languages, keyed by model.ReminderConfig.Language.
+// Add an entry here to support another language.
+var catalogs = map[string]*Catalog{
+	model.LanguageJapanese: {
+		DefaultTemplate: "【リマインド】{title}\n期限: {due_date} ({days_text})\n{url}",
+		DateFormat:      "2006-01-02",
+		Weekdays:        [7]string{"日", "月", "火", "水", "木", "金", "土"},
+		DaysText:        calculator.FormatDaysText,
+		OverdueText:     func(n int) string { return fmt.Sprintf("%d日超過", n) },
+		Late:            "【遅延】",
+	},
+	model.LanguageEnglish: {
+		DefaultTemplate: "[Reminder] {title}\nDue: {due_date} ({days_text})\n{url}",
+		DateFormat:      "Mon, Jan 2, 2006",
+		Weekdays:        [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		DaysText:        englishDaysText,
+		OverdueText:     englishOverdueText,
+		Late:            "[Late] ",
+	},
+}
+
+// CatalogFor returns the catalog for a language, falling back to Japanese
+func CatalogFor(language string) *Catalog {
+	if catalog, ok := catalogs[language]; ok {
+		return catalog
+	}
+	return catalogs[model.LanguageJapanese]
+}
+
+// HasCatalog reports whether a language has a built-in catalog
+func HasCatalog(language string) bool {
+	_, ok := catalogs[language]
+	return ok
+}
+
+// FormatDate formats a due date in the catalog's date format
+func (c *Catalog) FormatDate(t time.Time) string {
+	return t.Format(c.DateFormat)
+}
+
+// Weekday returns the catalog's name for the weekday of t
+func (c *Catalog) Weekday(t time.Time) string {
+	return c.Weekdays[t.Weekday()]
+}
+
+// lateDaysText phrases the time left until a due date for a reminder sent late,
+// counting from today rather than from the missed reminder date.
+// dueDate is already local, as returned by localDueDate.
+func (c *Catalog) lateDaysText(dueDate, today time.Time) string {
+	due := time.Date(dueDate.Year(), dueDate.Month(), dueDate.Day(), 0, 0, 0, 0, time.UTC)
+	day := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	days := int(due.Sub(day).Hours() / 24)
+	switch {
+	case days < 0:
+		return c.OverdueText(-days)
+	case days == 0:
+		return c.DaysText("当日")
+	default:
+		return c.DaysText(fmt.Sprintf("%d日前", days))
+	}
+}
+
+func englishOverdueText(n int) string {
+	if n == 1 {
+		return "1 day overdue"
+	}
+	return fmt.Sprintf("%d days overdue", n)
+}
+
+var timingPattern = regexp.MustCompile(`^(\d+)(日|営業日|週間)前$`)
+
+// englishDaysText phrases a timing such as "3営業日前" as "in 3 business days"
+func englishDaysText(timing string) string {
+	if timing == "当日" {
+		return "today"
+	}
+	match := timingPattern.FindStringSubmatch(timing)
+	if len(match) != 3 {
+		return timing
+	}
+	n, err := strconv.Atoi(match[1])
+	if err != nil {
+		return timing
+	}
+
+	unit := map[string]string{"日": "day", "営業日": "business day", "週間": "week"}[match[2]]
+	if n == 1 {
+		if unit == "day" {
+			return "tomorrow"
+		}
+		return fmt.Sprintf("in 1 %s", unit)
+	}
+	return fmt.Sprintf("in %d %ss", n, unit)
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"schedule-reminder/internal/domain/model"
+	"strings"
+	"time"
+)
+
+// Metric names
+const (
+	MetricNotificationsSent    = "NotificationsSent"
+	MetricNotificationsFailed  = "NotificationsFailed"
+	MetricNotificationsSkipped = "NotificationsSkipped"
+	MetricSchedulesEvaluated   = "SchedulesEvaluated"
+	MetricConfigErrors         = "ConfigErrors"
+	MetricNotionLatency        = "NotionLatency"
+	MetricSendLatency          = "SendLatency"
+)
+
+// Metric dimensions
+const (
+	DimensionConfig    = "Config"
+	DimensionChannel   = "Channel"
+	DimensionOperation = "Operation"
+)
+
+// MetricsSink receives the metrics of a run. Implementations must be safe for concurrent use.
+type MetricsSink interface {
+	Record(ctx context.Context, metric model.Metric)
+}
+
+type noopMetrics struct{}
+
+func (noopMetrics) Record(context.Context, model.Metric) {}
+
+// WithMetrics records counters and latencies of every run in sink
+func WithMetrics(sink MetricsSink) Option {
+	return func(s *ReminderService) {
+		s.metrics = sink
+	}
+}
+
+func (s *ReminderService) count(ctx context.Context, name string, value int, dimensions map[string]string) {
+	s.metrics.Record(ctx, model.Metric{Name: name, Unit: model.UnitCount, Value: float64(value), Dimensions: dimensions})
+}
+
+func (s *ReminderService) latency(ctx context.Context, name string, elapsed time.Duration, dimensions map[string]string) {
+	s.metrics.Record(ctx, model.Metric{
+		Name:       name,
+		Unit:       model.UnitMilliseconds,
+		Value:      float64(elapsed.Microseconds()) / 1000,
+		Dimensions: dimensions,
+	})
+}
+
+// recordDelivery counts the outcome of one notification
+func (s *ReminderService) recordDelivery(ctx context.Context, delivery DeliveryResult, config *model.ReminderConfig) {
+	// Rendered but unsent notifications would skew the delivery metrics
+	if s.dryRun {
+		return
+	}
+	name := MetricNotificationsSent
+	switch {
+	case delivery.Skipped():
+		name = MetricNotificationsSkipped
+	case delivery.Err != nil:
+		name = MetricNotificationsFailed
+	}
+	s.count(ctx, name, 1, channelDimensions(config, delivery.Channel))
+}
+
+func configDimensions(config *model.ReminderConfig) map[string]string {
+	return map[string]string{DimensionConfig: config.Name}
+}
+
+// channelDimensions uses the lowercase channel name so that "Slack" and "slack" are one metric
+func channelDimensions(config *model.ReminderConfig, channel string) map[string]string {
+	return map[string]string{DimensionConfig: config.Name, DimensionChannel: strings.ToLower(channel)}
+}
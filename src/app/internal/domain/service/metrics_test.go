@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"schedule-reminder/internal/domain/model"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeNotion serves fixed configs and schedules and records write-backs
+type fakeNotion struct {
+	configs    []*model.ReminderConfig
+	loadErrors []*model.ConfigLoadError
+	schedules  map[string][]*model.Schedule // Keyed by config ID; a missing key fails the fetch
+
+	mu       sync.Mutex
+	advanced []string // Schedule IDs passed to AdvanceSchedule
+	recorded []string // Schedule IDs passed to RecordReminders, once per call
+}
+
+func (f *fakeNotion) LoadReminderConfigs(ctx context.Context, masterDBID string) ([]*model.ReminderConfig, []*model.ConfigLoadError, error) {
+	return f.configs, f.loadErrors, nil
+}
+
+func (f *fakeNotion) FetchSchedules(ctx context.Context, config *model.ReminderConfig, today time.Time) ([]*model.Schedule, error) {
+	schedules, ok := f.schedules[config.ID]
+	if !ok {
+		return nil, errors.New("object_not_found")
+	}
+	return schedules, nil
+}
+
+func (f *fakeNotion) AdvanceSchedule(ctx context.Context, config *model.ReminderConfig, schedule *model.Schedule, next time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.advanced = append(f.advanced, schedule.ID)
+	return nil
+}
+
+func (f *fakeNotion) RecordReminders(ctx context.Context, config *model.ReminderConfig, schedule *model.Schedule, sent []model.SentReminder) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.recorded = append(f.recorded, schedule.ID)
+	return nil
+}
+
+func (f *fakeNotion) CreateComment(ctx context.Context, pageID string, message string, mentionUserIDs []string) error {
+	return nil
+}
+
+func (f *fakeNotion) DatabasePropertyNames(ctx context.Context, databaseID string) ([]string, error) {
+	return nil, nil
+}
+
+// recordingSink keeps every recorded metric
+type recordingSink struct {
+	mu      sync.Mutex
+	metrics []model.Metric
+}
+
+func (r *recordingSink) Record(ctx context.Context, metric model.Metric) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.metrics = append(r.metrics, metric)
+}
+
+// sum adds the values of the named metric recorded with the given dimensions
+func (r *recordingSink) sum(name string, dimensions map[string]string) float64 {
+	var total float64
+	for _, m := range r.metrics {
+		if m.Name != name || len(m.Dimensions) != len(dimensions) {
+			continue
+		}
+		match := true
+		for key, value := range dimensions {
+			if m.Dimensions[key] != value {
+				match = false
+			}
+		}
+		if match {
+			total += m.Value
+		}
+	}
+	return total
+}
+
+func TestProcessRemindersRecordsMetrics(t *testing.T) {
+	// The first notification succeeds and the second fails, whichever schedule sends first
+	var mu sync.Mutex
+	status := []int{http.StatusOK, http.StatusNotFound}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.WriteHeader(status[0])
+		status = status[1:]
+	}))
+	defer server.Close()
+
+	tz := time.UTC
+	now := time.Date(2026, 3, 9, 9, 0, 0, 0, tz) // A Monday
+	tomorrow := now.AddDate(0, 0, 1)
+	notion := &fakeNotion{
+		configs: []*model.ReminderConfig{
+			{
+				ID:                   "config-1",
+				Name:                 "週次",
+				ReminderTimings:      []string{"1日前"},
+				NotificationChannels: []string{"Slack"},
+				WebhookURL:           server.URL,
+				Language:             model.LanguageJapanese,
+				Timezone:             tz,
+			},
+			{ID: "config-2", Name: "壊れた設定", Language: model.LanguageJapanese, Timezone: tz},
+		},
+		loadErrors: []*model.ConfigLoadError{{PageID: "config-3", Name: "未設定", Err: errors.New("validation error")}},
+		schedules: map[string][]*model.Schedule{
+			"config-1": {
+				{ID: "page-1", Title: "定例会", DueDate: tomorrow},
+				{ID: "page-2", Title: "請求書送付", DueDate: tomorrow},
+				{ID: "page-3", Title: "来月の予定", DueDate: tomorrow.AddDate(0, 1, 0)},
+			},
+		},
+	}
+	sink := &recordingSink{}
+	s := NewReminderService(notion, "master", WithMetrics(sink), WithClock(func() time.Time { return now }))
+
+	if _, err := s.ProcessReminders(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	slack := map[string]string{DimensionConfig: "週次", DimensionChannel: "slack"}
+	checks := []struct {
+		name       string
+		dimensions map[string]string
+		want       float64
+	}{
+		{MetricNotificationsSent, slack, 1},
+		{MetricNotificationsFailed, slack, 1},
+		{MetricSchedulesEvaluated, map[string]string{DimensionConfig: "週次"}, 3},
+		{MetricConfigErrors, map[string]string{DimensionConfig: "壊れた設定"}, 1},
+		{MetricConfigErrors, map[string]string{DimensionConfig: "未設定"}, 1},
+	}
+	for _, c := range checks {
+		if got := sink.sum(c.name, c.dimensions); got != c.want {
+			t.Errorf("%s %v: got %v, want %v", c.name, c.dimensions, got, c.want)
+		}
+	}
+
+	var sendLatencies, notionLatencies int
+	for _, m := range sink.metrics {
+		switch m.Name {
+		case MetricSendLatency:
+			sendLatencies++
+		case MetricNotionLatency:
+			notionLatencies++
+		}
+		if (m.Name == MetricSendLatency || m.Name == MetricNotionLatency) && m.Unit != model.UnitMilliseconds {
+			t.Errorf("%s: got unit %q", m.Name, m.Unit)
+		}
+	}
+	if sendLatencies != 2 || notionLatencies != 3 {
+		t.Errorf("got %d send and %d Notion latencies, want 2 and 3", sendLatencies, notionLatencies)
+	}
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"schedule-reminder/internal/domain/model"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Concurrency limits for ProcessReminders
+const (
+	configConcurrency      = 4 // Configurations processed at once
+	scheduleConcurrency    = 8 // Schedules of one configuration processed at once
+	sendConcurrency        = 8 // Notifications in flight across all configurations
+	sendConcurrencyPerHost = 2 // Notifications in flight per destination host
+
+	// deadlineMargin is the time left before the context deadline after which
+	// no new notification is started, so the run can finish and report cleanly
+	deadlineMargin = 3 * time.Second
+)
+
+// errDeadlineNear is returned for notifications skipped because the run is about to time out
+var errDeadlineNear = errors.New("skipped: context deadline is too close")
+
+// runBounded calls fn for every index in [0, n) using at most limit goroutines
+// and returns once all calls have finished
+func runBounded(n, limit int, fn func(i int)) {
+	if limit > n {
+		limit = n
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < limit; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
+
+// sendLimiter bounds notifications in flight, overall and per destination host
+type sendLimiter struct {
+	slots   chan struct{}
+	perHost int
+
+	mu    sync.Mutex
+	hosts map[string]chan struct{}
+}
+
+func newSendLimiter(total, perHost int) *sendLimiter {
+	return &sendLimiter{
+		slots:   make(chan struct{}, total),
+		perHost: perHost,
+		hosts:   make(map[string]chan struct{}),
+	}
+}
+
+// acquire waits for a free slot for host. The returned function releases it.
+func (l *sendLimiter) acquire(ctx context.Context, host string) (func(), error) {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < deadlineMargin {
+		return nil, errDeadlineNear
+	}
+
+	hostSlots := l.hostSlots(host)
+	select {
+	case hostSlots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	select {
+	case l.slots <- struct{}{}:
+	case <-ctx.Done():
+		<-hostSlots
+		return nil, ctx.Err()
+	}
+
+	return func() {
+		<-l.slots
+		<-hostSlots
+	}, nil
+}
+
+func (l *sendLimiter) hostSlots(host string) chan struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	slots, ok := l.hosts[host]
+	if !ok {
+		slots = make(chan struct{}, l.perHost)
+		l.hosts[host] = slots
+	}
+	return slots
+}
+
+// hostFor returns the host a channel delivers to, used to apply per-host limits
+func hostFor(config *model.ReminderConfig, channel string) string {
+	switch strings.ToLower(channel) {
+	case "line":
+		return "api.line.me"
+	case "notion":
+		return "api.notion.com"
+	}
+	if u, err := url.Parse(config.WebhookURL); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return strings.ToLower(channel)
+}
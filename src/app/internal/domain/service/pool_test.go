@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunBoundedLimitsConcurrency(t *testing.T) {
+	var running, peak int32
+	results := make([]int, 20)
+
+	runBounded(len(results), 3, func(i int) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		results[i] = i * i
+		atomic.AddInt32(&running, -1)
+	})
+
+	if peak > 3 {
+		t.Fatalf("peak concurrency %d, want at most 3", peak)
+	}
+	for i, got := range results {
+		if got != i*i {
+			t.Fatalf("results[%d] = %d, want %d", i, got, i*i)
+		}
+	}
+}
+
+func TestSendLimiterPerHost(t *testing.T) {
+	limiter := newSendLimiter(4, 1)
+	ctx := context.Background()
+
+	release, err := limiter.acquire(ctx, "hooks.slack.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Another host is not blocked by the busy one
+	other, err := limiter.acquire(ctx, "discord.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	other()
+
+	// The same host waits until the slot is released
+	var wg sync.WaitGroup
+	acquired := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		r, err := limiter.acquire(ctx, "hooks.slack.com")
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		close(acquired)
+		r()
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("second send to the same host was not limited")
+	case <-time.After(20 * time.Millisecond):
+	}
+	release()
+	wg.Wait()
+}
+
+func TestSendLimiterSkipsNearDeadline(t *testing.T) {
+	limiter := newSendLimiter(1, 1)
+	ctx, cancel := context.WithTimeout(context.Background(), deadlineMargin/2)
+	defer cancel()
+
+	if _, err := limiter.acquire(ctx, "discord.com"); err != errDeadlineNear {
+		t.Fatalf("got %v, want errDeadlineNear", err)
+	}
+}
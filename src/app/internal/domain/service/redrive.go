@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"schedule-reminder/internal/domain/model"
+	"schedule-reminder/internal/infrastructure/logging"
+	"schedule-reminder/internal/infrastructure/notifier"
+)
+
+const (
+	redriveBatchSize   = 10
+	redriveMaxLetters  = 500 // Bounds one redrive run
+	redriveMaxAttempts = 5   // Failed redrives after which a letter is dropped
+)
+
+// RedriveResult counts the outcome of a redrive run
+type RedriveResult struct {
+	Redriven int `json:"redriven"`
+	Failed   int `json:"failed"`  // Delivery failed again; the letter is queued again with its progress
+	Skipped  int `json:"skipped"` // The config no longer exists or is disabled; the letter stays in the queue
+	Dropped  int `json:"dropped"` // Delivery failed permanently or too often; the letter is removed
+}
+
+// Redrive replays dead-lettered notifications. Destinations and credentials come
+// from the current configuration, so a fixed webhook URL or token takes effect.
+// Delivered letters are deleted. A letter that fails again is queued again with
+// the chunks delivered so far and one more attempt, unless the failure is
+// permanent or the attempts are used up, in which case it is dropped.
+func (s *ReminderService) Redrive(ctx context.Context) (*RedriveResult, error) {
+	if s.deadLetters == nil {
+		return nil, fmt.Errorf("no dead-letter queue configured")
+	}
+	if s.dryRun {
+		return nil, fmt.Errorf("dry run is not supported for redrive")
+	}
+
+	configs, _, err := s.notionClient.LoadReminderConfigs(ctx, s.masterDBID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configurations: %w", err)
+	}
+	configsByID := make(map[string]*model.ReminderConfig, len(configs))
+	for _, config := range configs {
+		configsByID[config.ID] = config
+	}
+
+	log := logging.FromContext(ctx)
+	started := s.clock()
+	result := &RedriveResult{}
+	for received := 0; received < redriveMaxLetters && ctx.Err() == nil; {
+		letters, err := s.deadLetters.Receive(ctx, redriveBatchSize)
+		if err != nil {
+			return result, err
+		}
+		if len(letters) == 0 {
+			break
+		}
+		received += len(letters)
+
+		for _, letter := range letters {
+			// Queued again by this run; it waits for the next redrive
+			if !letter.FailedAt.Before(started) {
+				continue
+			}
+
+			letterCtx, letterLog := logging.With(ctx,
+				logging.KeyConfigID, letter.ConfigID, logging.KeyConfigName, letter.ConfigName,
+				logging.KeyScheduleID, letter.ScheduleID, logging.KeySchedule, letter.ScheduleTitle,
+				logging.KeyTiming, letter.Timing, logging.KeyChannel, letter.Channel)
+
+			config, ok := configsByID[letter.ConfigID]
+			if !ok {
+				letterLog.Warn("skipping dead letter: config not found or disabled")
+				result.Skipped++
+				continue
+			}
+
+			notification := redriveNotification(letter, config)
+			if err := s.deliver(letterCtx, notification); err != nil {
+				letterLog.Error("failed to redrive notification", logging.KeyError, err)
+				if s.requeue(letterCtx, letter, err) {
+					result.Failed++
+				} else {
+					result.Dropped++
+				}
+				continue
+			}
+
+			s.recordHistory(letterCtx, notification.Schedule, config,
+				[]model.SentReminder{{Timing: letter.Timing, Channel: letter.Channel, SentAt: s.clock()}})
+			if err := s.deadLetters.Delete(letterCtx, letter); err != nil {
+				letterLog.Warn("redriven notification could not be removed from the queue", logging.KeyError, err)
+			}
+			result.Redriven++
+		}
+	}
+
+	log.Info("redrive finished", "redriven", result.Redriven, "failed", result.Failed,
+		"skipped", result.Skipped, "dropped", result.Dropped)
+	return result, nil
+}
+
+// requeue replaces a letter whose redrive failed with cause by one that records
+// the failure, or drops it when retrying cannot help. It reports whether the
+// letter is kept.
+func (s *ReminderService) requeue(ctx context.Context, letter *model.DeadLetter, cause error) bool {
+	log := logging.FromContext(ctx)
+	next := *letter
+	next.ReceiptHandle = ""
+	next.Attempts++
+	next.Error = cause.Error()
+	next.FailedAt = s.clock()
+	var deliveryErr *notifier.DeliveryError
+	permanent := errors.As(cause, &deliveryErr) && deliveryErr.Permanent
+	if deliveryErr != nil {
+		next.StatusCode = deliveryErr.StatusCode
+		next.SentChunks = max(next.SentChunks, deliveryErr.SentChunks)
+	}
+
+	if permanent || next.Attempts >= redriveMaxAttempts {
+		// The message is logged so that it can still be sent by hand
+		log.Error("dropping dead letter", "attempts", next.Attempts, "permanent", permanent,
+			"sentChunks", next.SentChunks, "message", next.Message)
+		if err := s.deadLetters.Delete(ctx, letter); err != nil {
+			log.Warn("dropped dead letter could not be removed from the queue", logging.KeyError, err)
+		}
+		return false
+	}
+
+	if err := s.deadLetters.Push(ctx, &next); err != nil {
+		// The original stays in the queue and is retried from its old progress
+		log.Warn("failed dead letter could not be queued again", logging.KeyError, err)
+		return true
+	}
+	if err := s.deadLetters.Delete(ctx, letter); err != nil {
+		log.Warn("failed dead letter could not be replaced in the queue", logging.KeyError, err)
+	}
+	return true
+}
+
+// redriveNotification rebuilds a notification from a dead letter, keeping the
+// rendered message but taking the destination from the current config
+func redriveNotification(letter *model.DeadLetter, config *model.ReminderConfig) *model.Notification {
+	schedule := &model.Schedule{
+		ID:        letter.ScheduleID,
+		Title:     letter.ScheduleTitle,
+		DueDate:   letter.DueDate,
+		NotionURL: letter.NotionURL,
+		PeopleIDs: letter.PeopleIDs,
+	}
+	return &model.Notification{
+		Schedule:    schedule,
+		Config:      config,
+		Timing:      letter.Timing,
+		Channel:     letter.Channel,
+		Message:     letter.Message,
+		Destination: destinationFor(schedule, config, letter.Channel),
+		SentChunks:  letter.SentChunks,
+	}
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"schedule-reminder/internal/domain/model"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeQueue hands out each queued letter once, like messages hidden by a visibility timeout
+type fakeQueue struct {
+	letters  []*model.DeadLetter
+	received int
+	deleted  []*model.DeadLetter
+}
+
+func (q *fakeQueue) Push(ctx context.Context, letter *model.DeadLetter) error {
+	q.letters = append(q.letters, letter)
+	return nil
+}
+
+func (q *fakeQueue) Receive(ctx context.Context, max int) ([]*model.DeadLetter, error) {
+	letters := q.letters[q.received:min(q.received+max, len(q.letters))]
+	q.received += len(letters)
+	return letters, nil
+}
+
+func (q *fakeQueue) Delete(ctx context.Context, letter *model.DeadLetter) error {
+	q.deleted = append(q.deleted, letter)
+	return nil
+}
+
+// queued returns the letters that were not deleted
+func (q *fakeQueue) queued() []*model.DeadLetter {
+	var queued []*model.DeadLetter
+	for _, letter := range q.letters {
+		deleted := false
+		for _, d := range q.deleted {
+			deleted = deleted || d == letter
+		}
+		if !deleted {
+			queued = append(queued, letter)
+		}
+	}
+	return queued
+}
+
+// newRedriveService redrives one letter of a three-chunk Slack message to server
+func newRedriveService(server *httptest.Server, sentChunks int) (*ReminderService, *fakeQueue, time.Time) {
+	now := time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC)
+	config := &model.ReminderConfig{ID: "config-1", WebhookURL: server.URL}
+	queue := &fakeQueue{letters: []*model.DeadLetter{{
+		ConfigID:   "config-1",
+		ScheduleID: "page-1",
+		Channel:    "Slack",
+		// Three chunks under Slack's 4000 character limit, told apart by their first letter
+		Message:    strings.Repeat("a", 3000) + "\n" + strings.Repeat("b", 3000) + "\n" + strings.Repeat("c", 3000),
+		SentChunks: sentChunks,
+		FailedAt:   now.AddDate(0, 0, -1),
+	}}}
+	s := NewReminderService(&fakeNotion{configs: []*model.ReminderConfig{config}}, "master",
+		WithDeadLetterQueue(queue), WithClock(func() time.Time { return now }))
+	s.retry = retryPolicy{maxAttempts: 2, baseDelay: time.Millisecond, maxDelay: time.Millisecond, maxRetryAfter: time.Second}
+	return s, queue, now
+}
+
+// chunkServer accepts chunks until one starts with failAt, which gets status
+func chunkServer(failAt string, status int, received *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Text string `json:"text"`
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+		if payload.Text[:1] == failAt {
+			w.WriteHeader(status)
+			return
+		}
+		*received = append(*received, payload.Text[:1])
+	}))
+}
+
+func TestRedriveNotificationUsesCurrentDestination(t *testing.T) {
+	letter := &model.DeadLetter{
+		ConfigID:      "config-1",
+		ScheduleID:    "page-1",
+		ScheduleTitle: "請求書送付",
+		Channel:       "Slack",
+		Message:       "【リマインド】請求書送付",
+		Destination:   "https://hooks.slack.com/services/old",
+	}
+	config := &model.ReminderConfig{ID: "config-1", WebhookURL: "https://hooks.slack.com/services/new"}
+
+	n := redriveNotification(letter, config)
+	if n.Destination != config.WebhookURL {
+		t.Fatalf("got destination %q, want %q", n.Destination, config.WebhookURL)
+	}
+	if n.Message != letter.Message || n.Schedule.ID != letter.ScheduleID {
+		t.Fatalf("rendered message or schedule not kept: %+v", n)
+	}
+
+	letter.Channel = "Notion"
+	if n := redriveNotification(letter, config); n.Destination != "page-1" {
+		t.Fatalf("got destination %q, want the schedule page", n.Destination)
+	}
+}
+
+func TestRedriveRequeuesPartialRedelivery(t *testing.T) {
+	var received []string
+	server := chunkServer("c", http.StatusServiceUnavailable, &received)
+	defer server.Close()
+	s, queue, now := newRedriveService(server, 1)
+
+	result, err := s.Redrive(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Failed != 1 || result.Dropped != 0 || result.Redriven != 0 {
+		t.Fatalf("got %+v, want one failed letter", result)
+	}
+	if got := strings.Join(received, ""); got != "b" {
+		t.Errorf("got chunks %q, want only b", got)
+	}
+
+	queued := queue.queued()
+	if len(queued) != 1 {
+		t.Fatalf("got %d queued letters, want the original replaced by one", len(queued))
+	}
+	letter := queued[0]
+	if letter.SentChunks != 2 || letter.Attempts != 1 || letter.StatusCode != http.StatusServiceUnavailable || !letter.FailedAt.Equal(now) {
+		t.Errorf("got sentChunks %d, attempts %d, status %d, failedAt %s; want 2, 1, 503, %s",
+			letter.SentChunks, letter.Attempts, letter.StatusCode, letter.FailedAt, now)
+	}
+}
+
+func TestRedriveDropsPermanentFailure(t *testing.T) {
+	var received []string
+	server := chunkServer("a", http.StatusNotFound, &received)
+	defer server.Close()
+	s, queue, _ := newRedriveService(server, 0)
+
+	result, err := s.Redrive(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Dropped != 1 || result.Failed != 0 {
+		t.Fatalf("got %+v, want one dropped letter", result)
+	}
+	if queued := queue.queued(); len(queued) != 0 {
+		t.Errorf("got %d queued letters, want none", len(queued))
+	}
+}
@@ -3,115 +3,438 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"schedule-reminder/internal/domain/calculator"
 	"schedule-reminder/internal/domain/model"
+	"schedule-reminder/internal/infrastructure/logging"
 	"schedule-reminder/internal/infrastructure/notifier"
+	"schedule-reminder/internal/infrastructure/tracing"
 	"strings"
 	"time"
+
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const (
-	sendMaxAttempts = 3
-	sendBaseDelay   = 500 * time.Millisecond
+	// deadLetterTimeout bounds storing a failed notification, which may happen after the run's deadline
+	deadLetterTimeout = 5 * time.Second
+
+	// recurrenceLookaheadDays bounds how far ahead recurring schedules are expanded.
+	// It must cover the longest reminder lead time (e.g. "8週間前").
+	recurrenceLookaheadDays = 90
 )
 
 // NotionClient interface for Notion operations
 type NotionClient interface {
-	LoadReminderConfigs(ctx context.Context, masterDBID string) ([]*model.ReminderConfig, error)
-	FetchSchedules(ctx context.Context, config *model.ReminderConfig, today time.Time) ([]*model.Schedule, error)
+	LoadReminderConfigs(ctx context.Context, masterDBID string) ([]*model.ReminderConfig, []*model.ConfigLoadError, error)
+	FetchSchedules(ctx context.Context, config *model.ReminderConfig, since time.Time) ([]*model.Schedule, error)
+	AdvanceSchedule(ctx context.Context, config *model.ReminderConfig, schedule *model.Schedule, next time.Time) error
+	RecordReminders(ctx context.Context, config *model.ReminderConfig, schedule *model.Schedule, sent []model.SentReminder) error
+	CreateComment(ctx context.Context, pageID string, message string, mentionUserIDs []string) error
+	DatabasePropertyNames(ctx context.Context, databaseID string) ([]string, error)
+}
+
+// DeadLetterQueue keeps notifications that failed after all retries
+type DeadLetterQueue interface {
+	Push(ctx context.Context, letter *model.DeadLetter) error
+	Receive(ctx context.Context, max int) ([]*model.DeadLetter, error)
+	Delete(ctx context.Context, letter *model.DeadLetter) error
 }
 
 // ReminderService orchestrates the reminder processing logic
 type ReminderService struct {
 	notionClient NotionClient
 	masterDBID   string
+	sendLimiter  *sendLimiter
+	retry        retryPolicy
+	deadLetters  DeadLetterQueue // Optional; failed notifications are lost when nil
+	metrics      MetricsSink
+	dryRun       bool // Render notifications without sending them or writing back to Notion
+	clock        Clock
+	runState     RunStateStore // Optional; enables catching up on missed days
+	catchUpDays  int           // How many days before today are caught up at most
+
+	// Overrides of a single run, e.g. to backfill a missed day
+	date            time.Time       // Zero means today
+	configScope     map[string]bool // Normalized config IDs; empty means all
+	scheduleScope   map[string]bool // Normalized schedule IDs; empty means all
+	channelOverride string          // Replaces the configured channels when set
+
+	operatorAlerts notifier.Notifier // Optional; receives a report of every run with failures
+}
+
+// Option configures optional dependencies of a ReminderService
+type Option func(*ReminderService)
+
+// WithDeadLetterQueue stores notifications that ultimately fail in q
+func WithDeadLetterQueue(q DeadLetterQueue) Option {
+	return func(s *ReminderService) {
+		s.deadLetters = q
+	}
 }
 
 // NewReminderService creates a new reminder service
-func NewReminderService(notionClient NotionClient, masterDBID string) *ReminderService {
-	return &ReminderService{
+func NewReminderService(notionClient NotionClient, masterDBID string, opts ...Option) *ReminderService {
+	s := &ReminderService{
 		notionClient: notionClient,
 		masterDBID:   masterDBID,
+		sendLimiter:  newSendLimiter(sendConcurrency, sendConcurrencyPerHost),
+		retry:        defaultRetryPolicy,
+		metrics:      noopMetrics{},
+		clock:        time.Now,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
-// ProcessReminders is the main entry point for processing reminders
-func (s *ReminderService) ProcessReminders(ctx context.Context) error {
+// ProcessReminders is the main entry point for processing reminders.
+// Configurations are processed concurrently and their results reported in load order.
+// An error is returned only when nothing could be processed; failures of single
+// configurations and notifications are counted in the report.
+func (s *ReminderService) ProcessReminders(ctx context.Context) (report *RunReport, err error) {
+	ctx, span := tracing.Start(ctx, "ProcessReminders")
+	defer func() { tracing.End(span, err) }()
+	log := logging.FromContext(ctx)
+
 	// Load all reminder configurations
-	configs, err := s.notionClient.LoadReminderConfigs(ctx, s.masterDBID)
+	start := time.Now()
+	configs, loadErrors, err := s.notionClient.LoadReminderConfigs(ctx, s.masterDBID)
+	s.latency(ctx, MetricNotionLatency, time.Since(start), map[string]string{DimensionOperation: "LoadReminderConfigs"})
 	if err != nil {
-		return fmt.Errorf("failed to load configurations: %w", err)
+		return nil, fmt.Errorf("failed to load configurations: %w", err)
+	}
+	configs, loadErrors = s.scopeConfigs(configs, loadErrors)
+	if len(s.configScope) > 0 && len(configs)+len(loadErrors) == 0 {
+		log.Warn("no enabled configuration matches the requested config IDs")
+	}
+	for _, loadErr := range loadErrors {
+		s.count(ctx, MetricConfigErrors, 1, map[string]string{DimensionConfig: displayName(loadErr.Name, loadErr.PageID)})
 	}
 
-	fmt.Printf("Loaded %d reminder configurations\n", len(configs))
+	log.Info("loaded reminder configurations", "configs", len(configs), "invalid", len(loadErrors))
 
-	// Process each configuration
-	totalNotifications := 0
+	s.validateTemplates(ctx, configs)
+	lastRuns := s.loadLastRunDates(ctx)
+
+	// Process configurations concurrently; one failing config does not stop the others
+	results := make([]*ConfigResult, len(configs))
+	runBounded(len(configs), configConcurrency, func(i int) {
+		results[i] = s.processConfig(ctx, configs[i], lastRuns[configs[i].ID])
+	})
+	runStateErr := s.saveLastRunDates(ctx, lastRuns, configs, results)
+
+	for _, result := range results {
+		configLog := log.With(logging.KeyConfigID, result.ConfigID, logging.KeyConfigName, result.Name)
+		if result.Err != nil {
+			configLog.Error("failed to process config", logging.KeyError, result.Err)
+			continue
+		}
+		configLog.Info("processed config", "sent", result.Sent(), "failed", result.Failed(), "skipped", result.Skipped())
+	}
+
+	report = newRunReport(results, loadErrors)
+	report.DryRun = s.dryRun
+	if runStateErr != nil {
+		report.RunStateError = runStateErr.Error()
+	}
+	span.SetAttributes(
+		attribute.Int("reminder.notifications.sent", report.NotificationsSent),
+		attribute.Int("reminder.notifications.failed", report.NotificationsFailed),
+		attribute.Int("reminder.notifications.skipped", report.NotificationsSkipped))
+	log.Info("processed reminders",
+		"sent", report.NotificationsSent, "failed", report.NotificationsFailed, "skipped", report.NotificationsSkipped)
+
+	s.alertOperators(ctx, report)
+	return report, nil
+}
+
+// validateTemplates reports unsupported languages and template variables that
+// match no property of the target database, so broken templates are noticed
+// before anything is sent
+func (s *ReminderService) validateTemplates(ctx context.Context, configs []*model.ReminderConfig) {
 	for _, config := range configs {
-		count, err := s.processConfig(ctx, config)
+		log := logging.FromContext(ctx).With(logging.KeyConfigID, config.ID, logging.KeyConfigName, config.Name)
+		if !HasCatalog(config.Language) {
+			log.Warn("unsupported language, using the default", "language", config.Language, "default", model.LanguageJapanese)
+		}
+
+		templates := map[string]string{}
+		if config.MessageTemplate != "" {
+			templates["template"] = config.MessageTemplate
+		}
+		for timing, template := range config.TimingTemplates {
+			templates[timing+" template"] = template
+		}
+		for channel, template := range config.ChannelTemplates {
+			templates[channel+" template"] = template
+		}
+		if len(templates) == 0 {
+			continue
+		}
+
+		propertyNames, err := s.notionClient.DatabasePropertyNames(ctx, config.TargetDatabaseID)
 		if err != nil {
-			fmt.Printf("Error processing config %s: %v\n", config.Name, err)
-			continue // Continue with other configs even if one fails
+			log.Warn("cannot validate templates", logging.KeyError, err)
+			continue
 		}
-		totalNotifications += count
-	}
 
-	fmt.Printf("Sent %d notifications total\n", totalNotifications)
-	return nil
+		for label, template := range templates {
+			if unknown := ValidateTemplate(template, propertyNames); len(unknown) > 0 {
+				log.Warn("template has unknown variables",
+					"template", label, "variables", unknown, "policy", config.UnresolvedPlaceholders)
+			}
+		}
+	}
 }
 
-// processConfig processes a single reminder configuration
-func (s *ReminderService) processConfig(ctx context.Context, config *model.ReminderConfig) (int, error) {
-	fmt.Printf("Processing: %s\n", config.Name)
+// processConfig processes a single reminder configuration, catching up on the
+// days missed since lastRun when catch-up is enabled.
+// Schedules are processed concurrently, but the notifications of one schedule are
+// sent in order so that write-backs to the same page never race.
+func (s *ReminderService) processConfig(ctx context.Context, config *model.ReminderConfig, lastRun string) *ConfigResult {
+	result := &ConfigResult{ConfigID: config.ID, Name: config.Name, URL: config.URL}
+	ctx, span := tracing.Start(ctx, "processConfig",
+		tracing.KeyConfigID.String(config.ID), tracing.KeyConfigName.String(config.Name))
+	defer func() { tracing.End(span, result.Err) }()
+
+	ctx, log := logging.With(ctx, logging.KeyConfigID, config.ID, logging.KeyConfigName, config.Name)
+	log.Info("processing config")
 
 	// Get today's date in the configured timezone
-	today := time.Now().In(config.Timezone)
+	today := s.today(config)
+
+	// Reminders of missed days are evaluated as of those days
+	missed := missedDays(lastRun, today, s.catchUpDays)
+	since := today
+	if len(missed) > 0 {
+		since = missed[0]
+		log.Info("catching up on missed days", "lastRun", lastRun, "since", since.Format("2006-01-02"), "days", len(missed))
+	}
 
 	// Fetch schedules from the target database
-	schedules, err := s.notionClient.FetchSchedules(ctx, config, today)
+	start := time.Now()
+	schedules, err := s.notionClient.FetchSchedules(ctx, config, since)
+	s.latency(ctx, MetricNotionLatency, time.Since(start), map[string]string{DimensionOperation: "FetchSchedules", DimensionConfig: config.Name})
 	if err != nil {
-		return 0, fmt.Errorf("failed to fetch schedules: %w", err)
+		result.Err = fmt.Errorf("failed to fetch schedules: %w", err)
+		s.count(ctx, MetricConfigErrors, 1, configDimensions(config))
+		return result
 	}
 
-	fmt.Printf("  Found %d schedules\n", len(schedules))
+	schedules = s.scopeSchedules(schedules)
+	result.Schedules = len(schedules)
+	s.count(ctx, MetricSchedulesEvaluated, len(schedules), configDimensions(config))
+	log.Info("found schedules", "schedules", len(schedules))
 
 	// Create business day calculator
-	holidays := loadHolidays(config.Timezone)
+	holidays := loadHolidays(ctx, config.Timezone)
 	calc := calculator.NewBusinessDayCalculator(holidays, config.Timezone)
 
-	// Process each schedule
-	notificationCount := 0
-	for _, schedule := range schedules {
-		// Evaluate which timings should trigger today
-		timings := s.evaluateTimings(schedule, config, today, calc)
-
-		if len(timings) > 0 {
-			fmt.Printf("    - %s (Due: %s) -> Timings: %v\n",
-				schedule.Title,
-				schedule.DueDate.Format("2006-01-02"),
-				timings)
-
-			// Send notifications for each triggered timing
-			for _, timing := range timings {
-				if err := s.sendNotification(ctx, schedule, config, timing); err != nil {
-					fmt.Printf("      Error sending notification: %v\n", err)
-					continue
+	// Process each schedule; results are collected per schedule to keep the order stable
+	scheduleResults := make([]*ConfigResult, len(schedules))
+	runBounded(len(schedules), scheduleConcurrency, func(i int) {
+		scheduleResults[i] = s.processSchedule(ctx, schedules[i], config, today, missed, calc)
+	})
+
+	for _, scheduleResult := range scheduleResults {
+		result.Deliveries = append(result.Deliveries, scheduleResult.Deliveries...)
+		result.Issues = append(result.Issues, scheduleResult.Issues...)
+	}
+	return result
+}
+
+// processSchedule sends the notifications due today, and late ones due on the
+// missed days, for one schedule and its occurrences, then advances a recurring
+// due date if configured.
+// The returned result holds only the schedule's deliveries and issues.
+func (s *ReminderService) processSchedule(ctx context.Context, schedule *model.Schedule, config *model.ReminderConfig, today time.Time, missed []time.Time, calc *calculator.BusinessDayCalculator) *ConfigResult {
+	result := &ConfigResult{}
+	ctx, log := logging.With(ctx, logging.KeyScheduleID, schedule.ID, logging.KeySchedule, schedule.Title)
+
+	days := append(append([]time.Time{}, missed...), today)
+	_, due, issues := s.dueReminders(schedule, config, days, calc)
+	for _, issue := range issues {
+		log.Warn("invalid schedule settings", logging.KeyError, issue.Err)
+	}
+	result.Issues = issues
+	if len(due) == 0 {
+		log.Debug("no reminders due", "dueDate", schedule.DueDate.Format("2006-01-02"))
+	}
+
+	var sent []model.SentReminder
+	for _, reminder := range due {
+		occurrence, timings := reminder.Occurrence, reminder.Timings
+		late := !calculator.IsSameDate(reminder.Day, today)
+		if late {
+			log.Info("late reminders due", "dueDate", occurrence.DueDate.Format("2006-01-02"), "timings", timings, "missedDate", reminder.Day.Format("2006-01-02"))
+		} else {
+			log.Info("reminders due", "dueDate", occurrence.DueDate.Format("2006-01-02"), "timings", timings)
+		}
+
+		// Send notifications for each triggered timing and channel
+		for _, timing := range timings {
+			for _, channel := range s.channels(config) {
+				notification, err := s.sendNotification(ctx, occurrence, config, timing, channel, late)
+				delivery := DeliveryResult{
+					ScheduleID: occurrence.ID,
+					Title:      occurrence.Title,
+					NotionURL:  occurrence.NotionURL,
+					DueDate:    occurrence.DueDate,
+					Timing:     timing,
+					Channel:    channel,
+					Late:       late,
+					Err:        err,
+				}
+				if s.dryRun && notification != nil {
+					delivery.Message = notification.Message
+					delivery.Destination = maskDestination(notification.Destination)
+				}
+				s.recordDelivery(ctx, delivery, config)
+				result.Deliveries = append(result.Deliveries, delivery)
+				if err == nil && !s.dryRun {
+					sent = append(sent, model.SentReminder{Timing: timing, Channel: channel, SentAt: s.clock()})
 				}
-				notificationCount++
 			}
 		}
 	}
+	s.recordHistory(ctx, schedule, config, sent)
 
-	return notificationCount, nil
+	if config.AutoAdvanceDueDate && ctx.Err() == nil {
+		s.advanceSchedule(ctx, schedule, config, today)
+	}
+	return result
 }
 
-// evaluateTimings determines which reminder timings should trigger today
-func (s *ReminderService) evaluateTimings(schedule *model.Schedule, config *model.ReminderConfig, today time.Time, calc *calculator.BusinessDayCalculator) []string {
+// advanceSchedule writes the next occurrence back to Notion once a recurring
+// schedule's due date has passed
+func (s *ReminderService) advanceSchedule(ctx context.Context, schedule *model.Schedule, config *model.ReminderConfig, today time.Time) {
+	if schedule.Recurrence == "" {
+		return
+	}
+
+	dueDate := schedule.DueDate.In(config.Timezone)
+	if !dueDate.Before(today) || calculator.IsSameDate(dueDate, today) {
+		return
+	}
+	if config.AdvanceOnlyWhenDone && !schedule.Completed {
+		return
+	}
+
+	rec, err := calculator.ParseRecurrence(schedule.Recurrence)
+	if err != nil || rec.Count > 0 {
+		// Already reported by expandOccurrences
+		return
+	}
+
+	next, ok := rec.Next(dueDate, today)
+	if !ok {
+		logging.FromContext(ctx).Info("recurrence has ended, leaving due date unchanged")
+		return
+	}
+
+	if s.dryRun {
+		logging.FromContext(ctx).Info("dry run: due date not advanced", "next", next.Format("2006-01-02"))
+		return
+	}
+	if !s.date.IsZero() {
+		logging.FromContext(ctx).Info("date override: due date not advanced", "next", next.Format("2006-01-02"))
+		return
+	}
+	if err := s.notionClient.AdvanceSchedule(ctx, config, schedule, next); err != nil {
+		logging.FromContext(ctx).Error("failed to advance due date", logging.KeyError, err)
+	}
+}
+
+// expandOccurrences returns the schedule itself, or one copy per occurrence from
+// the given day on when the schedule has a recurrence rule. Each copy carries the
+// occurrence as its DueDate.
+// An invalid rule is reported and the schedule is treated as non-recurring.
+// COUNT is reported for auto-advanced schedules: the rule is anchored on the
+// current due date, so every advance would restart the count.
+func expandOccurrences(schedule *model.Schedule, config *model.ReminderConfig, from, today time.Time) ([]*model.Schedule, error) {
+	if schedule.Recurrence == "" {
+		return []*model.Schedule{schedule}, nil
+	}
+
+	rec, err := calculator.ParseRecurrence(schedule.Recurrence)
+	if err != nil {
+		return []*model.Schedule{schedule}, fmt.Errorf("failed to parse recurrence %q: %w", schedule.Recurrence, err)
+	}
+
+	anchor := schedule.DueDate.In(config.Timezone)
+	dates := rec.Occurrences(anchor, from, today.AddDate(0, 0, recurrenceLookaheadDays))
+
+	occurrences := make([]*model.Schedule, 0, len(dates))
+	for _, date := range dates {
+		occurrence := *schedule
+		occurrence.DueDate = date
+		occurrences = append(occurrences, &occurrence)
+	}
+	if config.AutoAdvanceDueDate && rec.Count > 0 {
+		return occurrences, fmt.Errorf("recurrence %q: COUNT cannot be used when due dates are advanced automatically, use UNTIL instead", schedule.Recurrence)
+	}
+	return occurrences, nil
+}
+
+// dueReminder is an occurrence of a schedule whose timings fire on Day
+type dueReminder struct {
+	Occurrence *model.Schedule
+	Day        time.Time
+	Timings    []string
+}
+
+// dueReminders evaluates a schedule on each of days, oldest first, exactly as a
+// run on that day would. It returns the schedule's occurrences from the first
+// day on and, by occurrence then day, the timings that fire. Problems with the
+// schedule's settings are returned once each, although every occurrence and
+// day repeats them.
+func (s *ReminderService) dueReminders(schedule *model.Schedule, config *model.ReminderConfig, days []time.Time, calc *calculator.BusinessDayCalculator) ([]*model.Schedule, []dueReminder, []Issue) {
+	var issues []Issue
+	reported := make(map[string]bool)
+	addIssue := func(err error) {
+		if reported[err.Error()] {
+			return
+		}
+		reported[err.Error()] = true
+		issues = append(issues, Issue{
+			ScheduleID: schedule.ID,
+			Title:      schedule.Title,
+			NotionURL:  schedule.NotionURL,
+			Err:        err,
+		})
+	}
+
+	occurrences, err := expandOccurrences(schedule, config, days[0], days[len(days)-1])
+	if err != nil {
+		addIssue(err)
+	}
+
+	var due []dueReminder
+	for _, occurrence := range occurrences {
+		for _, day := range days {
+			timings, invalid := s.evaluateTimings(occurrence, config, day, calc)
+			for _, err := range invalid {
+				addIssue(err)
+			}
+			if len(timings) > 0 {
+				due = append(due, dueReminder{Occurrence: occurrence, Day: day, Timings: timings})
+			}
+		}
+	}
+	return occurrences, due, issues
+}
+
+// evaluateTimings determines which reminder timings should trigger today.
+// Timings that cannot be parsed are returned as errors.
+func (s *ReminderService) evaluateTimings(schedule *model.Schedule, config *model.ReminderConfig, today time.Time, calc *calculator.BusinessDayCalculator) ([]string, []error) {
 	var triggered []string
+	var invalid []error
 
 	timings := config.ReminderTimings
 	if len(schedule.ReminderTimings) > 0 {
@@ -121,7 +444,7 @@ func (s *ReminderService) evaluateTimings(schedule *model.Schedule, config *mode
 	for _, timing := range timings {
 		reminderDate, err := calculator.ParseAndCalculateReminderDate(schedule.DueDate, timing, calc)
 		if err != nil {
-			fmt.Printf("      Warning: failed to calculate reminder date for '%s': %v\n", timing, err)
+			invalid = append(invalid, fmt.Errorf("invalid reminder timing %q: %w", timing, err))
 			continue
 		}
 
@@ -130,111 +453,183 @@ func (s *ReminderService) evaluateTimings(schedule *model.Schedule, config *mode
 		}
 	}
 
-	return triggered
+	return triggered, invalid
 }
 
-// sendNotification sends a single notification
-func (s *ReminderService) sendNotification(ctx context.Context, schedule *model.Schedule, config *model.ReminderConfig, timing string) error {
+// sendNotification sends a single notification to one channel, dead-lettering it when delivery fails.
+// A late notification is one whose reminder date was missed.
+// The rendered notification is returned unless the message could not be built.
+func (s *ReminderService) sendNotification(ctx context.Context, schedule *model.Schedule, config *model.ReminderConfig, timing, channel string, late bool) (*model.Notification, error) {
 	// Build message from template
-	message := BuildMessage(schedule, config, timing)
-
-	destination := config.WebhookURL
-	if strings.ToLower(config.NotificationChannel) == "line" {
-		destination = config.LineRecipientID
+	var message string
+	var err error
+	if late {
+		message, err = BuildLateMessage(schedule, config, timing, channel, s.today(config))
+	} else {
+		message, err = BuildMessage(schedule, config, timing, channel)
 	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to build message: %w", err)
+	}
+
+	ctx, log := logging.With(ctx, logging.KeyTiming, timing, logging.KeyChannel, channel)
 
 	// Create notification
 	notification := &model.Notification{
 		Schedule:    schedule,
 		Config:      config,
 		Timing:      timing,
+		Channel:     channel,
 		Message:     message,
-		Destination: destination,
+		Destination: destinationFor(schedule, config, channel),
+	}
+
+	if s.dryRun {
+		log.Info("dry run: notification not sent",
+			"destination", maskDestination(notification.Destination), "message", notification.Message)
+		return notification, nil
+	}
+
+	if err := s.deliver(ctx, notification); err != nil {
+		log.Error("failed to send notification", logging.KeyError, err)
+		s.deadLetter(ctx, notification, err)
+		return notification, err
 	}
+	return notification, nil
+}
+
+// deliver sends a rendered notification within the send limits
+func (s *ReminderService) deliver(ctx context.Context, notification *model.Notification) error {
+	config := notification.Config
+	log := logging.FromContext(ctx)
 
 	// Create notifier
-	n, err := notifier.CreateNotifier(config)
+	n, err := notifier.CreateNotifier(config, notification.Channel, s.notionClient)
 	if err != nil {
 		return fmt.Errorf("failed to create notifier: %w", err)
 	}
 
-	// Send notification
-	if err := sendWithRetry(ctx, n, notification); err != nil {
+	// Send notification within the overall and per-host limits
+	release, err := s.sendLimiter.acquire(ctx, hostFor(config, notification.Channel))
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	err = s.retry.send(ctx, n, notification)
+	release()
+	s.latency(ctx, MetricSendLatency, time.Since(start), channelDimensions(config, notification.Channel))
+	if err != nil {
 		return fmt.Errorf("failed to send notification: %w", err)
 	}
 
-	fmt.Printf("      ✓ Sent %s notification\n", n.Type())
+	log.Info("sent notification", "notifier", n.Type())
 	return nil
 }
 
-func sendWithRetry(ctx context.Context, n notifier.Notifier, notification *model.Notification) error {
-	var lastErr error
-	for attempt := 1; attempt <= sendMaxAttempts; attempt++ {
-		if err := n.Send(ctx, notification); err != nil {
-			lastErr = err
-			if attempt == sendMaxAttempts {
-				break
-			}
-			delay := time.Duration(attempt) * sendBaseDelay
-			fmt.Printf("      Warning: send failed (%s), retrying in %s (attempt %d/%d)\n",
-				err, delay, attempt+1, sendMaxAttempts)
-			if !sleepWithContext(ctx, delay) {
-				return fmt.Errorf("retry canceled: %w", ctx.Err())
-			}
-			continue
-		}
-		return nil
+// recordHistory writes the reminders sent for a schedule back to its page in
+// one update when history is enabled
+func (s *ReminderService) recordHistory(ctx context.Context, schedule *model.Schedule, config *model.ReminderConfig, sent []model.SentReminder) {
+	// A run for another date must not leave its mark on the real schedule
+	if !config.RecordHistory || !s.date.IsZero() || len(sent) == 0 {
+		return
+	}
+	// Delivery already succeeded, so a failed write-back is only reported
+	if err := s.notionClient.RecordReminders(ctx, config, schedule, sent); err != nil {
+		logging.FromContext(ctx).Warn("failed to record reminder history", logging.KeyError, err)
 	}
-	return lastErr
 }
 
-func sleepWithContext(ctx context.Context, delay time.Duration) bool {
-	timer := time.NewTimer(delay)
-	defer timer.Stop()
+// deadLetter stores a notification that could not be delivered so it can be redriven later
+func (s *ReminderService) deadLetter(ctx context.Context, notification *model.Notification, cause error) {
+	log := logging.FromContext(ctx)
+	if s.deadLetters == nil {
+		log.Warn("notification is lost (no dead-letter queue configured)")
+		return
+	}
+
+	letter := &model.DeadLetter{
+		ConfigID:      notification.Config.ID,
+		ConfigName:    notification.Config.Name,
+		ScheduleID:    notification.Schedule.ID,
+		ScheduleTitle: notification.Schedule.Title,
+		NotionURL:     notification.Schedule.NotionURL,
+		PeopleIDs:     notification.Schedule.PeopleIDs,
+		DueDate:       notification.Schedule.DueDate,
+		Timing:        notification.Timing,
+		Channel:       notification.Channel,
+		Message:       notification.Message,
+		Destination:   notification.Destination,
+		Error:         cause.Error(),
+		FailedAt:      s.clock(),
+	}
+	var deliveryErr *notifier.DeliveryError
+	if errors.As(cause, &deliveryErr) {
+		letter.StatusCode = deliveryErr.StatusCode
+		letter.SentChunks = deliveryErr.SentChunks
+	}
 
-	select {
-	case <-ctx.Done():
-		return false
-	case <-timer.C:
-		return true
+	// The run may be out of time, but the failure must still be kept
+	pushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), deadLetterTimeout)
+	defer cancel()
+	if err := s.deadLetters.Push(pushCtx, letter); err != nil {
+		log.Error("failed to dead-letter notification", logging.KeyError, err)
+		return
+	}
+	log.Info("dead-lettered notification")
+}
+
+// destinationFor returns where a channel delivers: a webhook URL,
+// a LINE recipient or the schedule's Notion page
+func destinationFor(schedule *model.Schedule, config *model.ReminderConfig, channel string) string {
+	switch strings.ToLower(channel) {
+	case "line":
+		return config.LineRecipientID
+	case "notion":
+		return schedule.ID
+	default:
+		return config.WebhookURL
 	}
 }
 
 // loadHolidays loads holiday data from an external API.
-func loadHolidays(timezone *time.Location) []time.Time {
+func loadHolidays(ctx context.Context, timezone *time.Location) []time.Time {
 	holidayAPIURL := strings.TrimSpace(os.Getenv("HOLIDAY_API_URL"))
 	if holidayAPIURL == "" {
 		return []time.Time{}
 	}
 
+	ctx, span := tracing.Start(ctx, "loadHolidays")
+	defer span.End()
+
+	log := logging.FromContext(ctx)
 	client := &http.Client{Timeout: 5 * time.Second}
-	req, err := http.NewRequest("GET", holidayAPIURL, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", holidayAPIURL, nil)
 	if err != nil {
-		fmt.Printf("Warning: failed to create holiday API request: %v\n", err)
+		log.Warn("failed to create holiday API request", logging.KeyError, err)
 		return []time.Time{}
 	}
 
 	resp, err := client.Do(req)
 	if err != nil {
-		fmt.Printf("Warning: failed to fetch holidays: %v\n", err)
+		log.Warn("failed to fetch holidays", logging.KeyError, err)
 		return []time.Time{}
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		fmt.Printf("Warning: holiday API returned status %d\n", resp.StatusCode)
+		log.Warn("holiday API returned an error status", "status", resp.StatusCode)
 		return []time.Time{}
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		fmt.Printf("Warning: failed to read holiday API response: %v\n", err)
+		log.Warn("failed to read holiday API response", logging.KeyError, err)
 		return []time.Time{}
 	}
 
 	var payload map[string]string
 	if err := json.Unmarshal(body, &payload); err != nil {
-		fmt.Printf("Warning: failed to parse holiday API response: %v\n", err)
+		log.Warn("failed to parse holiday API response", logging.KeyError, err)
 		return []time.Time{}
 	}
 
@@ -242,11 +637,12 @@ func loadHolidays(timezone *time.Location) []time.Time {
 	for dateStr := range payload {
 		date, err := time.ParseInLocation("2006-01-02", dateStr, timezone)
 		if err != nil {
-			fmt.Printf("Warning: failed to parse holiday date %s: %v\n", dateStr, err)
+			log.Warn("failed to parse holiday date", "date", dateStr, logging.KeyError, err)
 			continue
 		}
 		holidays = append(holidays, date)
 	}
+	span.SetAttributes(attribute.Int("reminder.holidays", len(holidays)))
 
 	return holidays
 }
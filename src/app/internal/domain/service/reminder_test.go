@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"schedule-reminder/internal/domain/model"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAutoAdvanceRejectsCount(t *testing.T) {
+	now := time.Date(2026, 3, 12, 9, 0, 0, 0, time.UTC)
+	lastWeek := time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)
+	notion := &fakeNotion{
+		configs: []*model.ReminderConfig{
+			{ID: "config-1", Name: "週次", AutoAdvanceDueDate: true, Timezone: time.UTC},
+		},
+		schedules: map[string][]*model.Schedule{
+			"config-1": {
+				{ID: "page-1", Title: "定例会", DueDate: lastWeek, AllDay: true, Recurrence: "FREQ=WEEKLY"},
+				{ID: "page-2", Title: "全5回の研修", DueDate: lastWeek, AllDay: true, Recurrence: "FREQ=WEEKLY;COUNT=5"},
+			},
+		},
+	}
+	s := NewReminderService(notion, "master", WithClock(func() time.Time { return now }))
+
+	report, err := s.ProcessReminders(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Advancing would re-anchor the rule and restart the count
+	if want := []string{"page-1"}; !reflect.DeepEqual(notion.advanced, want) {
+		t.Errorf("advanced %v, want %v", notion.advanced, want)
+	}
+	failures := report.Configs[0].Failures
+	if len(failures) != 1 || failures[0].Schedule != "全5回の研修" || !strings.Contains(failures[0].Error, "COUNT") {
+		t.Errorf("unexpected failures %+v", failures)
+	}
+}
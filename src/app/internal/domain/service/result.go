@@ -0,0 +1,218 @@
+package service
+
+import (
+	"errors"
+	"schedule-reminder/internal/domain/model"
+	"time"
+)
+
+// DeliveryResult is the outcome of one notification to one channel
+type DeliveryResult struct {
+	ScheduleID string
+	Title      string
+	NotionURL  string
+	DueDate    time.Time
+	Timing     string
+	Channel    string
+	Late       bool // The reminder date was missed and the notification was caught up
+	Err        error
+
+	// Set in dry-run mode only
+	Message     string
+	Destination string // Masked
+}
+
+// Skipped reports whether the notification was never attempted because the run ran out of time
+func (d DeliveryResult) Skipped() bool {
+	return errors.Is(d.Err, errDeadlineNear)
+}
+
+// Issue is a problem with a schedule that did not stop processing, e.g. an invalid timing
+type Issue struct {
+	ScheduleID string
+	Title      string
+	NotionURL  string
+	Err        error
+}
+
+// report converts the issue for a report, naming config where one is needed
+func (i Issue) report(config string) FailureReport {
+	return FailureReport{Config: config, Schedule: i.Title, URL: i.NotionURL, Error: i.Err.Error()}
+}
+
+// ConfigResult is the outcome of one reminder configuration.
+// Deliveries are ordered by schedule, then timing, then channel, regardless of
+// the order in which they completed.
+type ConfigResult struct {
+	ConfigID   string
+	Name       string
+	URL        string
+	Schedules  int // Schedules fetched from the target database
+	Deliveries []DeliveryResult
+	Issues     []Issue
+	Err        error // Set when the configuration could not be processed at all
+}
+
+// Sent returns the number of successful deliveries
+func (r *ConfigResult) Sent() int {
+	sent := 0
+	for _, d := range r.Deliveries {
+		if d.Err == nil {
+			sent++
+		}
+	}
+	return sent
+}
+
+// Skipped returns the number of deliveries skipped near the deadline
+func (r *ConfigResult) Skipped() int {
+	skipped := 0
+	for _, d := range r.Deliveries {
+		if d.Skipped() {
+			skipped++
+		}
+	}
+	return skipped
+}
+
+// Failed returns the number of deliveries that were attempted and failed
+func (r *ConfigResult) Failed() int {
+	return len(r.Deliveries) - r.Sent() - r.Skipped()
+}
+
+// RunReport summarises one ProcessReminders run. It is returned as the Lambda response.
+type RunReport struct {
+	ConfigsProcessed     int            `json:"configsProcessed"`
+	ConfigsFailed        int            `json:"configsFailed"`
+	SchedulesEvaluated   int            `json:"schedulesEvaluated"`
+	NotificationsSent    int            `json:"notificationsSent"`
+	NotificationsFailed  int            `json:"notificationsFailed"`
+	NotificationsSkipped int            `json:"notificationsSkipped"`
+	DryRun               bool           `json:"dryRun,omitempty"`         // Notifications were rendered but not sent
+	RunStateError        string         `json:"runStateError,omitempty"`  // The last run dates were not saved; the next run may send reminders again
+	InvalidConfigs       []ConfigReport `json:"invalidConfigs,omitempty"` // Master database rows that could not be loaded
+	Configs              []ConfigReport `json:"configs"`
+}
+
+// ConfigReport is the part of a RunReport for one configuration
+type ConfigReport struct {
+	ID                   string          `json:"id"`
+	Name                 string          `json:"name"`
+	URL                  string          `json:"url,omitempty"`
+	Schedules            int             `json:"schedules"`
+	NotificationsSent    int             `json:"notificationsSent"`
+	NotificationsFailed  int             `json:"notificationsFailed"`
+	NotificationsSkipped int             `json:"notificationsSkipped"`
+	Error                string          `json:"error,omitempty"`    // Why the configuration could not be processed
+	Failures             []FailureReport `json:"failures,omitempty"` // Failed or skipped notifications and schedule issues
+	Previews             []PreviewReport `json:"previews,omitempty"` // Rendered notifications of a dry run
+}
+
+// FailureReport describes one failed notification or schedule issue
+type FailureReport struct {
+	Config   string `json:"config,omitempty"` // Set where the failures of several configurations are listed together
+	Schedule string `json:"schedule,omitempty"`
+	URL      string `json:"url,omitempty"`
+	Timing   string `json:"timing,omitempty"`
+	Channel  string `json:"channel,omitempty"`
+	Late     bool   `json:"late,omitempty"`
+	Error    string `json:"error"`
+}
+
+// PreviewReport is a notification rendered by a dry run
+type PreviewReport struct {
+	Schedule    string `json:"schedule"`
+	URL         string `json:"url,omitempty"`
+	DueDate     string `json:"dueDate"`
+	Timing      string `json:"timing"`
+	Channel     string `json:"channel"`
+	Destination string `json:"destination"`
+	Message     string `json:"message"`
+}
+
+// HasFailures reports whether the run had anything an operator should look at
+func (r *RunReport) HasFailures() bool {
+	if len(r.InvalidConfigs) > 0 || r.RunStateError != "" {
+		return true
+	}
+	for _, config := range r.Configs {
+		if config.Error != "" || len(config.Failures) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// newRunReport aggregates config results, keeping their order
+func newRunReport(results []*ConfigResult, loadErrors []*model.ConfigLoadError) *RunReport {
+	report := &RunReport{Configs: make([]ConfigReport, 0, len(results))}
+	for _, loadErr := range loadErrors {
+		report.InvalidConfigs = append(report.InvalidConfigs, ConfigReport{
+			ID:    loadErr.PageID,
+			Name:  loadErr.Name,
+			URL:   loadErr.URL,
+			Error: loadErr.Err.Error(),
+		})
+	}
+
+	for _, result := range results {
+		config := ConfigReport{
+			ID:                   result.ConfigID,
+			Name:                 result.Name,
+			URL:                  result.URL,
+			Schedules:            result.Schedules,
+			NotificationsSent:    result.Sent(),
+			NotificationsFailed:  result.Failed(),
+			NotificationsSkipped: result.Skipped(),
+		}
+		if result.Err != nil {
+			config.Error = result.Err.Error()
+			report.ConfigsFailed++
+		} else {
+			report.ConfigsProcessed++
+		}
+		for _, issue := range result.Issues {
+			config.Failures = append(config.Failures, issue.report(""))
+		}
+		for _, d := range result.Deliveries {
+			if d.Message != "" {
+				config.Previews = append(config.Previews, PreviewReport{
+					Schedule:    d.Title,
+					URL:         d.NotionURL,
+					DueDate:     d.DueDate.Format("2006-01-02"),
+					Timing:      d.Timing,
+					Channel:     d.Channel,
+					Destination: d.Destination,
+					Message:     d.Message,
+				})
+			}
+			if d.Err != nil {
+				config.Failures = append(config.Failures, FailureReport{
+					Schedule: d.Title,
+					URL:      d.NotionURL,
+					Timing:   d.Timing,
+					Channel:  d.Channel,
+					Late:     d.Late,
+					Error:    d.Err.Error(),
+				})
+			}
+		}
+
+		report.SchedulesEvaluated += config.Schedules
+		report.NotificationsSent += config.NotificationsSent
+		report.NotificationsFailed += config.NotificationsFailed
+		report.NotificationsSkipped += config.NotificationsSkipped
+		report.Configs = append(report.Configs, config)
+	}
+	return report
+}
+
+// Failures returns the number of configurations and notifications that did not
+// succeed, plus one when the last run dates could not be saved
+func (r *RunReport) Failures() int {
+	failures := len(r.InvalidConfigs) + r.ConfigsFailed + r.NotificationsFailed + r.NotificationsSkipped
+	if r.RunStateError != "" {
+		failures++
+	}
+	return failures
+}
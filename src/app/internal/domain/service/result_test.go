@@ -0,0 +1,38 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestNewRunReport(t *testing.T) {
+	results := []*ConfigResult{
+		{
+			ConfigID:  "a",
+			Name:      "週次",
+			Schedules: 3,
+			Deliveries: []DeliveryResult{
+				{Title: "レビュー", Timing: "当日", Channel: "Slack"},
+				{Title: "レビュー", Timing: "当日", Channel: "LINE", Err: errors.New("line push returned status 400")},
+				{Title: "請求", Timing: "1日前", Channel: "Slack", Err: fmt.Errorf("wrapped: %w", errDeadlineNear)},
+			},
+		},
+		{ConfigID: "b", Name: "月次", Err: errors.New("failed to fetch schedules: 404")},
+	}
+
+	report := newRunReport(results, nil)
+
+	if report.ConfigsProcessed != 1 || report.ConfigsFailed != 1 || report.SchedulesEvaluated != 3 {
+		t.Fatalf("unexpected config counts: %+v", report)
+	}
+	if report.NotificationsSent != 1 || report.NotificationsFailed != 1 || report.NotificationsSkipped != 1 {
+		t.Fatalf("unexpected notification counts: %+v", report)
+	}
+	if report.Failures() != 3 {
+		t.Fatalf("got %d failures, want 3", report.Failures())
+	}
+	if len(report.Configs) != 2 || report.Configs[0].Name != "週次" || len(report.Configs[0].Failures) != 2 || report.Configs[1].Error == "" {
+		t.Fatalf("unexpected per-config reports: %+v", report.Configs)
+	}
+}
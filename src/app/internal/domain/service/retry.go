@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"schedule-reminder/internal/domain/model"
+	"schedule-reminder/internal/infrastructure/logging"
+	"schedule-reminder/internal/infrastructure/notifier"
+	"schedule-reminder/internal/infrastructure/retry"
+	"schedule-reminder/internal/infrastructure/tracing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// retryPolicy decides whether and when a failed send is retried
+type retryPolicy struct {
+	maxAttempts   int
+	baseDelay     time.Duration // Delay before the first retry, doubled on each attempt
+	maxDelay      time.Duration // Cap on the backoff delay
+	maxRetryAfter time.Duration // Longer waits requested by the destination are not honoured; the send fails
+}
+
+var defaultRetryPolicy = retryPolicy{
+	maxAttempts:   3,
+	baseDelay:     500 * time.Millisecond,
+	maxDelay:      5 * time.Second,
+	maxRetryAfter: 15 * time.Second,
+}
+
+// send delivers a notification, retrying transient failures.
+// A retry of a split message resumes after the chunks already delivered.
+func (p retryPolicy) send(ctx context.Context, n notifier.Notifier, notification *model.Notification) error {
+	for attempt := 1; ; attempt++ {
+		sendCtx, span := tracing.Start(ctx, "Notifier.Send",
+			tracing.KeyChannel.String(n.Type()),
+			tracing.KeyTiming.String(notification.Timing),
+			attribute.Int("reminder.attempt", attempt))
+		err := n.Send(sendCtx, notification)
+		tracing.End(span, err)
+		if err == nil {
+			return nil
+		}
+		var deliveryErr *notifier.DeliveryError
+		if errors.As(err, &deliveryErr) && deliveryErr.SentChunks > notification.SentChunks {
+			resumed := *notification
+			resumed.SentChunks = deliveryErr.SentChunks
+			notification = &resumed
+		}
+
+		delay, retryable := p.delay(err, attempt)
+		if !retryable {
+			return err
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return fmt.Errorf("%w (no time left to retry)", err)
+		}
+
+		logging.FromContext(ctx).Warn("send failed, retrying", logging.KeyError, err,
+			"delay", delay.Round(time.Millisecond).String(), "attempt", attempt+1, "maxAttempts", p.maxAttempts)
+		if !retry.Sleep(ctx, delay) {
+			return fmt.Errorf("retry canceled: %w", ctx.Err())
+		}
+	}
+}
+
+// delay returns how long to wait before retrying after the given failed attempt,
+// or false when the error is permanent or attempts are exhausted
+func (p retryPolicy) delay(err error, attempt int) (time.Duration, bool) {
+	if attempt >= p.maxAttempts || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return 0, false
+	}
+
+	var deliveryErr *notifier.DeliveryError
+	if errors.As(err, &deliveryErr) {
+		if deliveryErr.Permanent {
+			return 0, false
+		}
+		if deliveryErr.RetryAfter > 0 {
+			if deliveryErr.RetryAfter > p.maxRetryAfter {
+				return 0, false
+			}
+			return deliveryErr.RetryAfter, true
+		}
+	}
+
+	return retry.Backoff(attempt, p.baseDelay, p.maxDelay), true
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"schedule-reminder/internal/domain/model"
+	"schedule-reminder/internal/infrastructure/notifier"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := retryPolicy{maxAttempts: 3, baseDelay: 100 * time.Millisecond, maxDelay: time.Second, maxRetryAfter: 10 * time.Second}
+
+	tests := []struct {
+		name      string
+		err       error
+		attempt   int
+		wantRetry bool
+		wantMin   time.Duration
+		wantMax   time.Duration
+	}{
+		{"permanent", &notifier.DeliveryError{StatusCode: 404, Permanent: true, Err: errors.New("gone")}, 1, false, 0, 0},
+		{"retry after", &notifier.DeliveryError{StatusCode: 429, RetryAfter: 2 * time.Second, Err: errors.New("slow down")}, 1, true, 2 * time.Second, 2 * time.Second},
+		{"retry after too long", &notifier.DeliveryError{StatusCode: 429, RetryAfter: time.Minute, Err: errors.New("slow down")}, 1, false, 0, 0},
+		{"server error backoff", &notifier.DeliveryError{StatusCode: 503, Err: errors.New("unavailable")}, 2, true, 100 * time.Millisecond, 200 * time.Millisecond},
+		{"untyped error", errors.New("boom"), 1, true, 50 * time.Millisecond, 100 * time.Millisecond},
+		{"attempts exhausted", errors.New("boom"), 3, false, 0, 0},
+	}
+
+	for _, tt := range tests {
+		delay, retry := policy.delay(tt.err, tt.attempt)
+		if retry != tt.wantRetry {
+			t.Fatalf("%s: got retry %v, want %v", tt.name, retry, tt.wantRetry)
+		}
+		if retry && (delay < tt.wantMin || delay > tt.wantMax) {
+			t.Fatalf("%s: got delay %s, want between %s and %s", tt.name, delay, tt.wantMin, tt.wantMax)
+		}
+	}
+}
+
+func TestRetryResumesSplitMessage(t *testing.T) {
+	var mu sync.Mutex
+	var received []string
+	failed := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Text string `json:"text"`
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+
+		mu.Lock()
+		defer mu.Unlock()
+		// The second chunk fails once
+		if len(received) == 1 && !failed {
+			failed = true
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		received = append(received, payload.Text[:1])
+	}))
+	defer server.Close()
+
+	// Three chunks under Slack's 4000 character limit, told apart by their first letter
+	message := strings.Repeat("a", 3000) + "\n" + strings.Repeat("b", 3000) + "\n" + strings.Repeat("c", 3000)
+	policy := retryPolicy{maxAttempts: 3, baseDelay: time.Millisecond, maxDelay: time.Millisecond, maxRetryAfter: time.Second}
+
+	err := policy.send(context.Background(), notifier.NewSlackNotifier(server.URL), &model.Notification{Message: message})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Join(received, ""); got != "abc" {
+		t.Errorf("got chunks %q, want each of abc exactly once", got)
+	}
+}
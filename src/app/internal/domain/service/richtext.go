@@ -0,0 +1,87 @@
+package service
+
+import (
+	"fmt"
+	"schedule-reminder/internal/domain/model"
+	"strings"
+)
+
+// FormatRichText renders rich text in the markup understood by the channel:
+// Slack mrkdwn, Discord markdown, or plain text (LINE, Notion and unknown channels)
+func FormatRichText(richText model.RichText, channel string) string {
+	var builder strings.Builder
+	for _, span := range richText {
+		switch strings.ToLower(channel) {
+		case "slack":
+			builder.WriteString(formatSlackSpan(span))
+		case "discord":
+			builder.WriteString(formatDiscordSpan(span))
+		default:
+			builder.WriteString(formatPlainSpan(span))
+		}
+	}
+	return builder.String()
+}
+
+func formatSlackSpan(span model.TextSpan) string {
+	text := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(span.Text)
+	if span.Code {
+		text = wrapSpan(text, "`")
+	} else {
+		if span.Bold {
+			text = wrapSpan(text, "*")
+		}
+		if span.Italic {
+			text = wrapSpan(text, "_")
+		}
+		if span.Strikethrough {
+			text = wrapSpan(text, "~")
+		}
+	}
+	if span.Link != "" {
+		text = fmt.Sprintf("<%s|%s>", span.Link, text)
+	}
+	return text
+}
+
+func formatDiscordSpan(span model.TextSpan) string {
+	text := span.Text
+	if span.Code {
+		text = wrapSpan(text, "`")
+	} else {
+		if span.Bold {
+			text = wrapSpan(text, "**")
+		}
+		if span.Italic {
+			text = wrapSpan(text, "*")
+		}
+		if span.Underline {
+			text = wrapSpan(text, "__")
+		}
+		if span.Strikethrough {
+			text = wrapSpan(text, "~~")
+		}
+	}
+	if span.Link != "" {
+		text = fmt.Sprintf("[%s](%s)", text, span.Link)
+	}
+	return text
+}
+
+func formatPlainSpan(span model.TextSpan) string {
+	if span.Link != "" && span.Link != span.Text {
+		return fmt.Sprintf("%s (%s)", span.Text, span.Link)
+	}
+	return span.Text
+}
+
+// wrapSpan surrounds text with a marker, keeping surrounding whitespace outside
+// because markdown emphasis does not open or close next to a space
+func wrapSpan(text, marker string) string {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
+	}
+	start := strings.Index(text, trimmed)
+	return text[:start] + marker + trimmed + marker + text[start+len(trimmed):]
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"schedule-reminder/internal/domain/model"
+	"strings"
+	"time"
+)
+
+// Clock returns the current time
+type Clock func() time.Time
+
+// WithClock replaces the wall clock, which decides today's date and the time
+// recorded for sent and failed notifications
+func WithClock(clock Clock) Option {
+	return func(s *ReminderService) {
+		s.clock = clock
+	}
+}
+
+// WithDate processes reminders as if today were the given date, e.g. to
+// backfill a day missed during an outage. Only the year, month and day are
+// used; each configuration reads them in its own timezone. Nothing is written
+// back to Notion: due dates are not advanced and history is not recorded.
+func WithDate(date time.Time) Option {
+	return func(s *ReminderService) {
+		s.date = date
+	}
+}
+
+// WithScope restricts a run to the given configurations and schedules.
+// Empty lists do not restrict. IDs match with or without dashes.
+func WithScope(configIDs, scheduleIDs []string) Option {
+	return func(s *ReminderService) {
+		s.configScope = idSet(configIDs)
+		s.scheduleScope = idSet(scheduleIDs)
+	}
+}
+
+// WithChannelOverride sends every notification to channel instead of the
+// configured channels. The configuration must still hold the channel's credentials.
+func WithChannelOverride(channel string) Option {
+	return func(s *ReminderService) {
+		s.channelOverride = channel
+	}
+}
+
+// today returns the current date and time in the configuration's timezone,
+// moved to the overridden date when one is set
+func (s *ReminderService) today(config *model.ReminderConfig) time.Time {
+	now := s.clock().In(config.Timezone)
+	if s.date.IsZero() {
+		return now
+	}
+	year, month, day := s.date.Date()
+	return time.Date(year, month, day, now.Hour(), now.Minute(), now.Second(), now.Nanosecond(), config.Timezone)
+}
+
+// channels returns the channels a configuration's notifications are sent to
+func (s *ReminderService) channels(config *model.ReminderConfig) []string {
+	if s.channelOverride != "" {
+		return []string{s.channelOverride}
+	}
+	return config.NotificationChannels
+}
+
+// scopeConfigs drops configurations and load errors outside the configured scope
+func (s *ReminderService) scopeConfigs(configs []*model.ReminderConfig, loadErrors []*model.ConfigLoadError) ([]*model.ReminderConfig, []*model.ConfigLoadError) {
+	if len(s.configScope) == 0 {
+		return configs, loadErrors
+	}
+
+	var scopedConfigs []*model.ReminderConfig
+	for _, config := range configs {
+		if s.configScope[normalizeID(config.ID)] {
+			scopedConfigs = append(scopedConfigs, config)
+		}
+	}
+	var scopedErrors []*model.ConfigLoadError
+	for _, loadErr := range loadErrors {
+		if s.configScope[normalizeID(loadErr.PageID)] {
+			scopedErrors = append(scopedErrors, loadErr)
+		}
+	}
+	return scopedConfigs, scopedErrors
+}
+
+// scopeSchedules drops schedules outside the configured scope
+func (s *ReminderService) scopeSchedules(schedules []*model.Schedule) []*model.Schedule {
+	if len(s.scheduleScope) == 0 {
+		return schedules
+	}
+
+	var scoped []*model.Schedule
+	for _, schedule := range schedules {
+		if s.scheduleScope[normalizeID(schedule.ID)] {
+			scoped = append(scoped, schedule)
+		}
+	}
+	return scoped
+}
+
+func idSet(ids []string) map[string]bool {
+	set := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if id = normalizeID(id); id != "" {
+			set[id] = true
+		}
+	}
+	return set
+}
+
+// normalizeID makes Notion IDs comparable whether or not they contain dashes
+func normalizeID(id string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(id), "-", ""))
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"schedule-reminder/internal/domain/model"
+	"testing"
+	"time"
+)
+
+func TestTodayWithDateOverride(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	now := time.Date(2026, 10, 19, 0, 30, 0, 0, time.UTC) // 09:30 in Tokyo
+	config := &model.ReminderConfig{Timezone: jst}
+
+	s := NewReminderService(nil, "master", WithClock(func() time.Time { return now }))
+	if got := s.today(config); !got.Equal(now) {
+		t.Errorf("without override: got %v, want %v", got, now)
+	}
+
+	s = NewReminderService(nil, "master",
+		WithClock(func() time.Time { return now }),
+		WithDate(time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)))
+	want := time.Date(2026, 10, 12, 9, 30, 0, 0, jst)
+	if got := s.today(config); !got.Equal(want) {
+		t.Errorf("with override: got %v, want %v", got, want)
+	}
+}
+
+func TestScopeConfigsNormalizesIDs(t *testing.T) {
+	s := NewReminderService(nil, "master", WithScope([]string{"A1B2C3D4E5F67890ABCDEF1234567890"}, nil))
+	configs := []*model.ReminderConfig{
+		{ID: "a1b2c3d4-e5f6-7890-abcd-ef1234567890"},
+		{ID: "f9e8d7c6-b5a4-3210-fedc-ba0987654321"},
+	}
+	loadErrors := []*model.ConfigLoadError{{PageID: "f9e8d7c6-b5a4-3210-fedc-ba0987654321"}}
+
+	scoped, scopedErrors := s.scopeConfigs(configs, loadErrors)
+	if len(scoped) != 1 || scoped[0] != configs[0] || len(scopedErrors) != 0 {
+		t.Fatalf("got %v and %v", scoped, scopedErrors)
+	}
+}
+
+func TestProcessRemindersWithOverrides(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	due := time.Date(2026, 3, 11, 0, 0, 0, 0, jst)
+	notion := &fakeNotion{
+		configs: []*model.ReminderConfig{
+			{
+				ID:                   "config-1",
+				Name:                 "週次",
+				ReminderTimings:      []string{"1日前"},
+				NotificationChannels: []string{"Slack"},
+				WebhookURL:           "https://discord.com/api/webhooks/1/token",
+				MessageTemplate:      "{title}",
+				Language:             model.LanguageJapanese,
+				Timezone:             jst,
+			},
+			{ID: "config-2", Name: "対象外", Timezone: jst},
+		},
+		schedules: map[string][]*model.Schedule{
+			"config-1": {
+				{ID: "page-1", Title: "定例会", DueDate: due},
+				{ID: "page-2", Title: "対象外の予定", DueDate: due},
+			},
+		},
+	}
+	s := NewReminderService(notion, "master",
+		WithDryRun(),
+		WithDate(time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)),
+		WithScope([]string{"config-1"}, []string{"page-1"}),
+		WithChannelOverride("Discord"))
+
+	report, err := s.ProcessReminders(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.Configs) != 1 || report.Configs[0].ID != "config-1" {
+		t.Fatalf("config scope not applied: %+v", report.Configs)
+	}
+	config := report.Configs[0]
+	if config.Schedules != 1 || len(config.Previews) != 1 {
+		t.Fatalf("schedule scope or date override not applied: %+v", config)
+	}
+	if preview := config.Previews[0]; preview.Channel != "Discord" || preview.Message != "定例会" {
+		t.Errorf("unexpected preview %+v", preview)
+	}
+}
+
+func TestDateOverrideDoesNotAdvance(t *testing.T) {
+	now := time.Date(2026, 3, 12, 9, 0, 0, 0, time.UTC)
+	notion := &fakeNotion{
+		configs: []*model.ReminderConfig{
+			{ID: "config-1", Name: "週次", AutoAdvanceDueDate: true, Timezone: time.UTC},
+		},
+		schedules: map[string][]*model.Schedule{
+			"config-1": {
+				{ID: "page-1", Title: "定例会", DueDate: now.AddDate(0, 0, 1), AllDay: true, Recurrence: "FREQ=WEEKLY"},
+			},
+		},
+	}
+	// The due date has passed on the overridden date, but not really
+	s := NewReminderService(notion, "master",
+		WithClock(func() time.Time { return now }),
+		WithDate(now.AddDate(0, 0, 7)))
+
+	if _, err := s.ProcessReminders(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(notion.advanced) != 0 {
+		t.Errorf("advanced %v under a date override", notion.advanced)
+	}
+}
@@ -2,39 +2,183 @@ package service
 
 import (
 	"fmt"
-	"schedule-reminder/internal/domain/calculator"
+	"regexp"
 	"schedule-reminder/internal/domain/model"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 )
 
-// BuildMessage builds a notification message from template
-func BuildMessage(schedule *model.Schedule, config *model.ReminderConfig, timing string) string {
-	template := config.MessageTemplate
-	if schedule.MessageTemplate != "" {
-		// Use schedule-specific template as-is when provided.
-		return schedule.MessageTemplate
-	}
-	if template == "" {
-		// Default template
-		template = "【リマインド】{title}\n期限: {due_date} ({days_text})\n{url}"
-	}
+// builtinVariables are the template variables that do not come from a property
+var builtinVariables = []string{"title", "due_date", "weekday", "days_text", "url", "description", "late"}
+
+var placeholderPattern = regexp.MustCompile(`\{([^{}\s]+)\}`)
+
+// UnresolvedPlaceholderError reports placeholders left in a message under the fail policy
+type UnresolvedPlaceholderError struct {
+	Placeholders []string
+}
+
+func (e *UnresolvedPlaceholderError) Error() string {
+	return fmt.Sprintf("unresolved template placeholders: %s", strings.Join(e.Placeholders, ", "))
+}
+
+// BuildMessage builds a notification message from template.
+// Rich text values are rendered in the markup of the given channel, and dates and
+// relative days in the config's language.
+// Placeholders that match nothing are handled by the config's UnresolvedPlaceholders policy.
+func BuildMessage(schedule *model.Schedule, config *model.ReminderConfig, timing, channel string) (string, error) {
+	return buildMessage(schedule, config, timing, channel, time.Time{})
+}
 
+// BuildLateMessage builds the message of a reminder whose date was missed and
+// that is sent today instead. It is marked as late, with {late} or a leading
+// marker, and {days_text} counts from today.
+func BuildLateMessage(schedule *model.Schedule, config *model.ReminderConfig, timing, channel string, today time.Time) (string, error) {
+	return buildMessage(schedule, config, timing, channel, today)
+}
+
+// buildMessage renders a message; a non-zero lateToday marks it as late
+func buildMessage(schedule *model.Schedule, config *model.ReminderConfig, timing, channel string, lateToday time.Time) (string, error) {
+	catalog := CatalogFor(config.Language)
+	template := SelectTemplate(schedule, config, timing, channel)
+	late := !lateToday.IsZero()
+	if late && !strings.Contains(template, "{late}") {
+		template = "{late}" + template
+	}
 	message := template
 
+	// Placeholders are resolved against the template, so braces inside
+	// substituted values are never mistaken for placeholders
+	propertyNames := make([]string, 0, len(schedule.Properties))
+	for key := range schedule.Properties {
+		propertyNames = append(propertyNames, key)
+	}
+	unresolved := ValidateTemplate(template, propertyNames)
+	if len(unresolved) > 0 && config.UnresolvedPlaceholders == model.PlaceholderFail {
+		return "", &UnresolvedPlaceholderError{Placeholders: unresolved}
+	}
+	if config.UnresolvedPlaceholders == model.PlaceholderBlank {
+		for _, name := range unresolved {
+			message = strings.ReplaceAll(message, "{"+name+"}", "")
+		}
+	}
+
 	// Replace variables
-	message = strings.ReplaceAll(message, "{title}", schedule.Title)
-	message = strings.ReplaceAll(message, "{due_date}", schedule.DueDate.Format("2006-01-02"))
-	message = strings.ReplaceAll(message, "{days_text}", calculator.FormatDaysText(timing))
+	message = strings.ReplaceAll(message, "{title}", richValue(schedule, channel, schedule.Title, config.TitlePropertyName))
+	dueDate := localDueDate(schedule, config)
+	message = strings.ReplaceAll(message, "{due_date}", catalog.FormatDate(dueDate))
+	message = strings.ReplaceAll(message, "{weekday}", catalog.Weekday(dueDate))
+	daysText, lateText := catalog.DaysText(timing), ""
+	if late {
+		daysText, lateText = catalog.lateDaysText(dueDate, lateToday), catalog.Late
+	}
+	message = strings.ReplaceAll(message, "{days_text}", daysText)
+	message = strings.ReplaceAll(message, "{late}", lateText)
 	message = strings.ReplaceAll(message, "{url}", schedule.NotionURL)
-	message = strings.ReplaceAll(message, "{description}", schedule.Description)
+	message = strings.ReplaceAll(message, "{description}", richValue(schedule, channel, schedule.Description, "説明", "Description"))
 
-	// Replace custom properties
+	// Replace custom properties; properties that exist but are empty render as ""
 	for key, value := range schedule.Properties {
 		placeholder := fmt.Sprintf("{%s}", strings.ToLower(key))
+		if rt, ok := schedule.RichText[key]; ok && len(rt) > 0 {
+			message = strings.ReplaceAll(message, placeholder, FormatRichText(rt, channel))
+			continue
+		}
 		if value != nil {
-			message = strings.ReplaceAll(message, placeholder, fmt.Sprintf("%v", value))
+			message = strings.ReplaceAll(message, placeholder, formatValue(value))
+		} else {
+			message = strings.ReplaceAll(message, placeholder, "")
 		}
 	}
 
-	return message
+	return message, nil
+}
+
+// localDueDate returns the due date as it reads in the config's timezone.
+// All-day dates are kept as they are, since converting them could change the day.
+func localDueDate(schedule *model.Schedule, config *model.ReminderConfig) time.Time {
+	if schedule.AllDay || config.Timezone == nil {
+		return schedule.DueDate
+	}
+	return schedule.DueDate.In(config.Timezone)
+}
+
+// SelectTemplate picks the most specific template for the timing that fired and
+// the channel being rendered: schedule per-timing, schedule, config per-timing,
+// config per-channel, config, then the default of the config's language
+func SelectTemplate(schedule *model.Schedule, config *model.ReminderConfig, timing, channel string) string {
+	candidates := []string{
+		schedule.TimingTemplates[timing],
+		schedule.MessageTemplate,
+		config.TimingTemplates[timing],
+		config.ChannelTemplates[strings.ToLower(channel)],
+		config.MessageTemplate,
+	}
+	for _, template := range candidates {
+		if template != "" {
+			return template
+		}
+	}
+	return CatalogFor(config.Language).DefaultTemplate
+}
+
+// TemplateVariables returns the distinct placeholder names used in a template, in order of appearance
+func TemplateVariables(template string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, match := range placeholderPattern.FindAllStringSubmatch(template, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			names = append(names, match[1])
+		}
+	}
+	return names
+}
+
+// ValidateTemplate returns the template variables that match neither a
+// built-in variable nor one of the given database property names
+func ValidateTemplate(template string, propertyNames []string) []string {
+	known := make(map[string]bool, len(builtinVariables)+len(propertyNames))
+	for _, name := range builtinVariables {
+		known[name] = true
+	}
+	for _, name := range propertyNames {
+		known[strings.ToLower(name)] = true
+	}
+
+	var unknown []string
+	for _, name := range TemplateVariables(template) {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// richValue returns the formatted rich text of the first matching property,
+// falling back to the plain value
+func richValue(schedule *model.Schedule, channel, plain string, names ...string) string {
+	for _, name := range names {
+		if rt, ok := schedule.RichText[name]; ok && len(rt) > 0 {
+			return FormatRichText(rt, channel)
+		}
+	}
+	return plain
+}
+
+// formatValue renders a property value for a message, joining lists with ", "
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []string:
+		return strings.Join(v, ", ")
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
 }
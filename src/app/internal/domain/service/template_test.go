@@ -0,0 +1,207 @@
+package service
+
+import (
+	"schedule-reminder/internal/domain/model"
+	"testing"
+	"time"
+)
+
+func TestFormatRichText(t *testing.T) {
+	richText := model.RichText{
+		{Text: "締切は "},
+		{Text: "金曜", Bold: true},
+		{Text: " です。"},
+		{Text: "手順書", Link: "https://example.com/doc"},
+	}
+
+	tests := []struct {
+		channel string
+		want    string
+	}{
+		{"Slack", "締切は *金曜* です。<https://example.com/doc|手順書>"},
+		{"Discord", "締切は **金曜** です。[手順書](https://example.com/doc)"},
+		{"LINE", "締切は 金曜 です。手順書 (https://example.com/doc)"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatRichText(richText, tt.channel); got != tt.want {
+			t.Fatalf("channel %q: got %q, want %q", tt.channel, got, tt.want)
+		}
+	}
+}
+
+func TestBuildMessageUsesAllRichTextSegments(t *testing.T) {
+	schedule := &model.Schedule{
+		Title:       "週次レビュー",
+		Description: "議題は前日まで",
+		RichText: map[string]model.RichText{
+			"説明": {{Text: "議題は"}, {Text: "前日まで", Bold: true}},
+		},
+	}
+	config := &model.ReminderConfig{MessageTemplate: "{title}: {description}"}
+
+	assertMessage(t, schedule, config, "Slack", "週次レビュー: 議題は*前日まで*")
+	assertMessage(t, schedule, config, "LINE", "週次レビュー: 議題は前日まで")
+}
+
+func TestBuildMessageFormatsPropertyValues(t *testing.T) {
+	schedule := &model.Schedule{
+		Title: "リリース",
+		Properties: map[string]interface{}{
+			"担当者": []string{"山田", "佐藤"},
+			"見積":  float64(2.5),
+			"関連":  []string{},
+		},
+	}
+	config := &model.ReminderConfig{MessageTemplate: "{担当者} / {見積} / {関連}"}
+
+	assertMessage(t, schedule, config, "Slack", "山田, 佐藤 / 2.5 / ")
+}
+
+func TestBuildMessageUnresolvedPlaceholders(t *testing.T) {
+	schedule := &model.Schedule{
+		Title:      "リリース",
+		Properties: map[string]interface{}{"担当者": nil},
+	}
+	template := "{title} {担当者}{assignee}"
+
+	tests := []struct {
+		policy      string
+		want        string
+		expectError bool
+	}{
+		{model.PlaceholderLeave, "リリース {assignee}", false},
+		{model.PlaceholderBlank, "リリース ", false},
+		{model.PlaceholderFail, "", true},
+	}
+
+	for _, tt := range tests {
+		config := &model.ReminderConfig{MessageTemplate: template, UnresolvedPlaceholders: tt.policy}
+		got, err := BuildMessage(schedule, config, "当日", "Slack")
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("policy %q: expected error, got %q", tt.policy, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("policy %q: unexpected error: %v", tt.policy, err)
+		}
+		if got != tt.want {
+			t.Fatalf("policy %q: got %q, want %q", tt.policy, got, tt.want)
+		}
+	}
+}
+
+func TestValidateTemplate(t *testing.T) {
+	got := ValidateTemplate("{title} {担当者} {Status} {assignee}", []string{"タイトル", "担当者", "status"})
+	if len(got) != 2 || got[0] != "Status" || got[1] != "assignee" {
+		t.Fatalf("got %v, want [Status assignee]", got)
+	}
+}
+
+func assertMessage(t *testing.T, schedule *model.Schedule, config *model.ReminderConfig, channel, want string) {
+	t.Helper()
+	got, err := BuildMessage(schedule, config, "当日", channel)
+	if err != nil {
+		t.Fatalf("channel %q: unexpected error: %v", channel, err)
+	}
+	if got != want {
+		t.Fatalf("channel %q: got %q, want %q", channel, got, want)
+	}
+}
+
+func TestBuildMessageSelectsTemplateByTiming(t *testing.T) {
+	schedule := &model.Schedule{
+		Title:           "請求書送付",
+		MessageTemplate: "{title} は{days_text}です",
+	}
+	config := &model.ReminderConfig{
+		MessageTemplate: "{title}",
+		TimingTemplates: map[string]string{"当日": "【本日締切】{title}"},
+	}
+
+	got, err := BuildMessage(schedule, config, "1日前", "Slack")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "請求書送付 は明日です"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	// A schedule template still wins over the config's per-timing template
+	if got := SelectTemplate(schedule, config, "当日", "Slack"); got != schedule.MessageTemplate {
+		t.Fatalf("got %q, want schedule template", got)
+	}
+
+	schedule.TimingTemplates = map[string]string{"当日": "{title} は今日まで！"}
+	if got := SelectTemplate(schedule, config, "当日", "Slack"); got != "{title} は今日まで！" {
+		t.Fatalf("got %q, want schedule timing template", got)
+	}
+}
+
+func TestBuildMessageLanguage(t *testing.T) {
+	schedule := &model.Schedule{
+		Title:     "Invoice",
+		DueDate:   time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+		NotionURL: "https://notion.so/page",
+	}
+
+	config := &model.ReminderConfig{Language: model.LanguageEnglish}
+	got, err := BuildMessage(schedule, config, "3営業日前", "Slack")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "[Reminder] Invoice\nDue: Fri, Mar 15, 2024 (in 3 business days)\nhttps://notion.so/page"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	config = &model.ReminderConfig{Language: model.LanguageJapanese, MessageTemplate: "{due_date}({weekday}) {days_text}"}
+	assertMessage(t, schedule, config, "Slack", "2024-03-15(金) 今日")
+
+	// Unknown languages fall back to Japanese
+	config = &model.ReminderConfig{Language: "fr", MessageTemplate: "{weekday} {days_text}"}
+	assertMessage(t, schedule, config, "Slack", "金 今日")
+}
+
+func TestBuildMessageFormatsDueDateInConfigTimezone(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skip("timezone data unavailable")
+	}
+	config := &model.ReminderConfig{Timezone: tokyo, MessageTemplate: "{due_date}({weekday})"}
+
+	// 20:00 UTC on Friday is Saturday morning in Tokyo
+	schedule := &model.Schedule{Title: "締切", DueDate: time.Date(2024, 3, 15, 20, 0, 0, 0, time.UTC)}
+	assertMessage(t, schedule, config, "Slack", "2024-03-16(土)")
+
+	// All-day dates keep their day
+	schedule = &model.Schedule{Title: "締切", DueDate: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC), AllDay: true}
+	assertMessage(t, schedule, config, "Slack", "2024-03-15(金)")
+}
+
+func TestBuildLateMessage(t *testing.T) {
+	schedule := &model.Schedule{Title: "定例会", DueDate: time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC)}
+	today := time.Date(2026, 3, 12, 9, 0, 0, 0, time.UTC)
+
+	config := &model.ReminderConfig{Language: model.LanguageJapanese, MessageTemplate: "{title} {days_text}"}
+	got, err := BuildLateMessage(schedule, config, "1日前", "Slack", today)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "【遅延】定例会 1日超過"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	config = &model.ReminderConfig{Language: model.LanguageEnglish, MessageTemplate: "{title} ({late}{days_text})"}
+	got, err = BuildLateMessage(schedule, config, "1日前", "Slack", today)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "定例会 ([Late] 1 day overdue)"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	// {late} is empty in regular reminders
+	assertMessage(t, schedule, config, "Slack", "定例会 (today)")
+}
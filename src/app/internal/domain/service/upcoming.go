@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"schedule-reminder/internal/domain/calculator"
+	"schedule-reminder/internal/domain/model"
+	"schedule-reminder/internal/infrastructure/logging"
+	"schedule-reminder/internal/infrastructure/tracing"
+	"sort"
+	"time"
+)
+
+// MaxUpcomingDays bounds how far ahead Upcoming looks
+const MaxUpcomingDays = 366
+
+// UpcomingReport lists the reminders that would fire in the coming days
+type UpcomingReport struct {
+	From      string             `json:"from"` // Earliest first day of the configurations, each in its own timezone; UTC without configurations
+	Days      int                `json:"days"`
+	Reminders []UpcomingReminder `json:"reminders"`
+	Issues    []FailureReport    `json:"issues,omitempty"` // Configurations and schedules whose reminders could not be listed completely
+}
+
+// UpcomingReminder is one notification that would be sent on Date
+type UpcomingReminder struct {
+	Date       string `json:"date"`
+	ConfigID   string `json:"configId"`
+	Config     string `json:"config"`
+	ScheduleID string `json:"scheduleId"`
+	Schedule   string `json:"schedule"`
+	URL        string `json:"url,omitempty"`
+	DueDate    string `json:"dueDate"`
+	Timing     string `json:"timing"`
+	Channel    string `json:"channel"`
+}
+
+// Upcoming lists every reminder that would fire from today for the given number
+// of days, evaluated exactly as ProcessReminders would on each day. Nothing is
+// sent or written back. Date, scope and channel overrides apply.
+func (s *ReminderService) Upcoming(ctx context.Context, days int) (report *UpcomingReport, err error) {
+	ctx, span := tracing.Start(ctx, "Upcoming")
+	defer func() { tracing.End(span, err) }()
+
+	if days < 1 || days > MaxUpcomingDays {
+		return nil, fmt.Errorf("days must be between 1 and %d: %d", MaxUpcomingDays, days)
+	}
+
+	configs, loadErrors, err := s.notionClient.LoadReminderConfigs(ctx, s.masterDBID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configurations: %w", err)
+	}
+	configs, loadErrors = s.scopeConfigs(configs, loadErrors)
+
+	report = &UpcomingReport{Days: days, Reminders: []UpcomingReminder{}}
+	for _, loadErr := range loadErrors {
+		report.Issues = append(report.Issues, FailureReport{
+			Config: displayName(loadErr.Name, loadErr.PageID),
+			URL:    loadErr.URL,
+			Error:  loadErr.Err.Error(),
+		})
+	}
+	report.From = s.today(&model.ReminderConfig{Timezone: time.UTC}).Format("2006-01-02")
+	for i, config := range configs {
+		// Each configuration's window starts on its own today
+		if from := s.today(config).Format("2006-01-02"); i == 0 || from < report.From {
+			report.From = from
+		}
+	}
+
+	results := make([]*UpcomingReport, len(configs))
+	runBounded(len(configs), configConcurrency, func(i int) {
+		results[i] = s.upcomingForConfig(ctx, configs[i], days)
+	})
+	for _, result := range results {
+		report.Reminders = append(report.Reminders, result.Reminders...)
+		report.Issues = append(report.Issues, result.Issues...)
+	}
+
+	// Configurations stay in load order within a day
+	sort.SliceStable(report.Reminders, func(i, j int) bool {
+		return report.Reminders[i].Date < report.Reminders[j].Date
+	})
+
+	logging.FromContext(ctx).Info("listed upcoming reminders", "days", days, "reminders", len(report.Reminders), "issues", len(report.Issues))
+	return report, nil
+}
+
+// upcomingForConfig lists the upcoming reminders of one configuration
+func (s *ReminderService) upcomingForConfig(ctx context.Context, config *model.ReminderConfig, days int) *UpcomingReport {
+	result := &UpcomingReport{}
+	ctx, _ = logging.With(ctx, logging.KeyConfigID, config.ID, logging.KeyConfigName, config.Name)
+
+	today := s.today(config)
+
+	schedules, err := s.notionClient.FetchSchedules(ctx, config, today)
+	if err != nil {
+		result.Issues = append(result.Issues, FailureReport{
+			Config: config.Name,
+			URL:    config.URL,
+			Error:  fmt.Errorf("failed to fetch schedules: %w", err).Error(),
+		})
+		return result
+	}
+	schedules = s.scopeSchedules(schedules)
+
+	calc := calculator.NewBusinessDayCalculator(loadHolidays(ctx, config.Timezone), config.Timezone)
+
+	window := daysFrom(today, days)
+	for _, schedule := range schedules {
+		_, due, issues := s.dueReminders(schedule, config, window, calc)
+		for _, issue := range issues {
+			result.Issues = append(result.Issues, issue.report(config.Name))
+		}
+		for _, reminder := range due {
+			occurrence := reminder.Occurrence
+			for _, timing := range reminder.Timings {
+				for _, channel := range s.channels(config) {
+					result.Reminders = append(result.Reminders, UpcomingReminder{
+						Date:       reminder.Day.Format("2006-01-02"),
+						ConfigID:   config.ID,
+						Config:     config.Name,
+						ScheduleID: occurrence.ID,
+						Schedule:   occurrence.Title,
+						URL:        occurrence.NotionURL,
+						DueDate:    occurrence.DueDate.In(config.Timezone).Format("2006-01-02"),
+						Timing:     timing,
+						Channel:    channel,
+					})
+				}
+			}
+		}
+	}
+	return result
+}
+
+// daysFrom returns n consecutive days starting with first
+func daysFrom(first time.Time, n int) []time.Time {
+	days := make([]time.Time, n)
+	for i := range days {
+		days[i] = first.AddDate(0, 0, i)
+	}
+	return days
+}
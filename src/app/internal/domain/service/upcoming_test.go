@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"schedule-reminder/internal/domain/model"
+	"testing"
+	"time"
+)
+
+func TestUpcoming(t *testing.T) {
+	now := time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC) // Monday
+	notion := &fakeNotion{
+		configs: []*model.ReminderConfig{
+			{
+				ID:                   "config-1",
+				Name:                 "週次",
+				ReminderTimings:      []string{"当日", "1営業日前"},
+				NotificationChannels: []string{"Slack", "Discord"},
+				Timezone:             time.UTC,
+			},
+			{ID: "config-2", Name: "壊れた設定", Timezone: time.UTC},
+		},
+		schedules: map[string][]*model.Schedule{
+			"config-1": {
+				// Monday: reminded on Friday and Monday, the latter outside the window
+				{ID: "page-1", Title: "定例会", DueDate: time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC)},
+				{ID: "page-2", Title: "請求書送付", DueDate: time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC), ReminderTimings: []string{"当日", "明後日"}},
+			},
+		},
+	}
+	s := NewReminderService(notion, "master", WithClock(func() time.Time { return now }), WithChannelOverride("Slack"))
+
+	report, err := s.Upcoming(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"2026-03-10 請求書送付 当日", "2026-03-13 定例会 1営業日前"}
+	var got []string
+	for _, r := range report.Reminders {
+		if r.Channel != "Slack" {
+			t.Errorf("channel override not applied: %+v", r)
+		}
+		got = append(got, r.Date+" "+r.Schedule+" "+r.Timing)
+	}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// The failed config and the invalid timing are reported once each
+	if report.From != "2026-03-09" || len(report.Issues) != 2 {
+		t.Errorf("got from %s and issues %+v", report.From, report.Issues)
+	}
+
+	if _, err := s.Upcoming(context.Background(), 0); err == nil {
+		t.Error("expected an error for 0 days")
+	}
+}
+
+func TestUpcomingFromIsEarliestConfigDay(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	now := time.Date(2026, 3, 9, 20, 0, 0, 0, time.UTC) // Already Tuesday in Tokyo
+	notion := &fakeNotion{
+		configs: []*model.ReminderConfig{
+			{ID: "config-1", Name: "東京", Timezone: tokyo},
+			{ID: "config-2", Name: "UTC", Timezone: time.UTC},
+		},
+		schedules: map[string][]*model.Schedule{"config-1": nil, "config-2": nil},
+	}
+	s := NewReminderService(notion, "master", WithClock(func() time.Time { return now }))
+
+	report, err := s.Upcoming(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.From != "2026-03-09" {
+		t.Errorf("got from %s, want the UTC configuration's today", report.From)
+	}
+}
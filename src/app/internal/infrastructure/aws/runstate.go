@@ -0,0 +1,34 @@
+package aws
+
+import "context"
+
+// runStateParameter is the path holding the last run date of each configuration,
+// one parameter per config ID so the state grows without hitting the 4 KB limit,
+// e.g. /lambda-functions/schedule-reminder/param-last-run-date/<config ID>
+const runStateParameter = "LAST_RUN_DATE"
+
+// RunStateStore keeps the date each configuration was last processed in Parameter Store
+type RunStateStore struct {
+	ssm *SSMClient
+}
+
+// NewRunStateStore creates a run state store backed by Parameter Store
+func NewRunStateStore(ssm *SSMClient) *RunStateStore {
+	return &RunStateStore{ssm: ssm}
+}
+
+// LastRunDates returns the last run date ("2006-01-02") keyed by config ID.
+// No parameters means no run has been recorded yet.
+func (s *RunStateStore) LastRunDates(ctx context.Context) (map[string]string, error) {
+	return s.ssm.GetParametersByPath(ctx, runStateParameter)
+}
+
+// SaveLastRunDate stores the last run date of one configuration
+func (s *RunStateStore) SaveLastRunDate(ctx context.Context, configID, date string) error {
+	return s.ssm.PutParameter(ctx, runStateParameter+"/"+configID, date)
+}
+
+// DeleteLastRunDate forgets the last run date of a configuration
+func (s *RunStateStore) DeleteLastRunDate(ctx context.Context, configID string) error {
+	return s.ssm.DeleteParameter(ctx, runStateParameter+"/"+configID)
+}
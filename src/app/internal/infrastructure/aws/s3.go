@@ -0,0 +1,54 @@
+package aws
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// CalendarBucket publishes calendar feeds to an S3 bucket
+type CalendarBucket struct {
+	client *s3.Client
+	bucket string
+}
+
+// NewCalendarBucket creates an S3 client for the bucket
+// It automatically configures for LocalStack when AWS_ENDPOINT_URL is set
+func NewCalendarBucket(ctx context.Context, bucket string) (*CalendarBucket, error) {
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	client := s3.NewFromConfig(cfg, func(o *s3.Options) {
+		// Override endpoint for LocalStack if AWS_ENDPOINT_URL is set
+		if endpoint := os.Getenv("AWS_ENDPOINT_URL"); endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+			o.UsePathStyle = true
+		}
+	})
+
+	return &CalendarBucket{
+		client: client,
+		bucket: bucket,
+	}, nil
+}
+
+// Put replaces the feed at key
+func (b *CalendarBucket) Put(ctx context.Context, key string, feed []byte) error {
+	if _, err := b.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:       aws.String(b.bucket),
+		Key:          aws.String(key),
+		Body:         bytes.NewReader(feed),
+		ContentType:  aws.String("text/calendar; charset=utf-8"),
+		CacheControl: aws.String("max-age=3600"),
+	}); err != nil {
+		return fmt.Errorf("failed to put s3://%s/%s: %w", b.bucket, key, err)
+	}
+	return nil
+}
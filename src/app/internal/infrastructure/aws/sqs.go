@@ -0,0 +1,101 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+
+	"schedule-reminder/internal/domain/model"
+	"schedule-reminder/internal/infrastructure/logging"
+)
+
+// sqsMaxMessages is the most messages SQS returns per receive call
+const sqsMaxMessages = 10
+
+// DeadLetterQueue stores undeliverable notifications in an SQS queue
+type DeadLetterQueue struct {
+	client   *sqs.Client
+	queueURL string
+}
+
+// NewDeadLetterQueue creates an SQS-backed dead-letter queue
+// It automatically configures for LocalStack when AWS_ENDPOINT_URL is set
+func NewDeadLetterQueue(ctx context.Context, queueURL string) (*DeadLetterQueue, error) {
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	client := sqs.NewFromConfig(cfg, func(o *sqs.Options) {
+		// Override endpoint for LocalStack if AWS_ENDPOINT_URL is set
+		if endpoint := os.Getenv("AWS_ENDPOINT_URL"); endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+		}
+	})
+
+	return &DeadLetterQueue{
+		client:   client,
+		queueURL: queueURL,
+	}, nil
+}
+
+// Push adds a failed notification to the queue
+func (q *DeadLetterQueue) Push(ctx context.Context, letter *model.DeadLetter) error {
+	body, err := json.Marshal(letter)
+	if err != nil {
+		return fmt.Errorf("failed to marshal dead letter: %w", err)
+	}
+
+	if _, err := q.client.SendMessage(ctx, &sqs.SendMessageInput{
+		QueueUrl:    aws.String(q.queueURL),
+		MessageBody: aws.String(string(body)),
+	}); err != nil {
+		return fmt.Errorf("failed to send dead letter to %s: %w", q.queueURL, err)
+	}
+	return nil
+}
+
+// Receive fetches up to max failed notifications. Received messages stay hidden
+// from other receivers until their visibility timeout expires or they are deleted.
+func (q *DeadLetterQueue) Receive(ctx context.Context, max int) ([]*model.DeadLetter, error) {
+	if max > sqsMaxMessages {
+		max = sqsMaxMessages
+	}
+
+	result, err := q.client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+		QueueUrl:            aws.String(q.queueURL),
+		MaxNumberOfMessages: int32(max),
+		WaitTimeSeconds:     1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to receive dead letters from %s: %w", q.queueURL, err)
+	}
+
+	letters := make([]*model.DeadLetter, 0, len(result.Messages))
+	for _, message := range result.Messages {
+		var letter model.DeadLetter
+		if err := json.Unmarshal([]byte(aws.ToString(message.Body)), &letter); err != nil {
+			logging.FromContext(ctx).Warn("skipping malformed dead letter", "messageId", aws.ToString(message.MessageId), logging.KeyError, err)
+			continue
+		}
+		letter.ReceiptHandle = aws.ToString(message.ReceiptHandle)
+		letters = append(letters, &letter)
+	}
+	return letters, nil
+}
+
+// Delete removes a received notification from the queue
+func (q *DeadLetterQueue) Delete(ctx context.Context, letter *model.DeadLetter) error {
+	if _, err := q.client.DeleteMessage(ctx, &sqs.DeleteMessageInput{
+		QueueUrl:      aws.String(q.queueURL),
+		ReceiptHandle: aws.String(letter.ReceiptHandle),
+	}); err != nil {
+		return fmt.Errorf("failed to delete dead letter: %w", err)
+	}
+	return nil
+}
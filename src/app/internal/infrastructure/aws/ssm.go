@@ -2,6 +2,7 @@ package aws
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -9,6 +10,9 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+
+	"schedule-reminder/internal/infrastructure/logging"
 )
 
 // SSMClient is a client for AWS Systems Manager Parameter Store
@@ -72,6 +76,62 @@ func (c *SSMClient) GetParameter(ctx context.Context, paramName string) (string,
 	return *result.Parameter.Value, nil
 }
 
+// PutParameter stores a plain string parameter, overwriting any previous value.
+// paramName follows the same convention as GetParameter.
+func (c *SSMClient) PutParameter(ctx context.Context, paramName, value string) error {
+	paramPath := c.buildParameterPath(paramName)
+
+	_, err := c.client.PutParameter(ctx, &ssm.PutParameterInput{
+		Name:      aws.String(paramPath),
+		Value:     aws.String(value),
+		Type:      types.ParameterTypeString,
+		Overwrite: aws.Bool(true),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put parameter %s: %w", paramPath, err)
+	}
+	return nil
+}
+
+// GetParametersByPath returns the plain string parameters below paramName,
+// keyed by the rest of their name: "LAST_RUN_DATE" returns ".../param-last-run-date/<key>"
+func (c *SSMClient) GetParametersByPath(ctx context.Context, paramName string) (map[string]string, error) {
+	paramPath := c.buildParameterPath(paramName)
+
+	values := make(map[string]string)
+	paginator := ssm.NewGetParametersByPathPaginator(c.client, &ssm.GetParametersByPathInput{
+		Path: aws.String(paramPath),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get parameters by path %s: %w", paramPath, err)
+		}
+		for _, param := range page.Parameters {
+			if param.Name == nil || param.Value == nil {
+				continue
+			}
+			values[strings.TrimPrefix(*param.Name, paramPath+"/")] = *param.Value
+		}
+	}
+	return values, nil
+}
+
+// DeleteParameter removes a parameter; a parameter that does not exist is not an error.
+// paramName follows the same convention as GetParameter.
+func (c *SSMClient) DeleteParameter(ctx context.Context, paramName string) error {
+	paramPath := c.buildParameterPath(paramName)
+
+	_, err := c.client.DeleteParameter(ctx, &ssm.DeleteParameterInput{
+		Name: aws.String(paramPath),
+	})
+	var notFound *types.ParameterNotFound
+	if err != nil && !errors.As(err, &notFound) {
+		return fmt.Errorf("failed to delete parameter %s: %w", paramPath, err)
+	}
+	return nil
+}
+
 // GetParameterWithFallback retrieves a parameter from Parameter Store,
 // falling back to an environment variable if not found
 func (c *SSMClient) GetParameterWithFallback(ctx context.Context, paramName string) (string, error) {
@@ -84,7 +144,7 @@ func (c *SSMClient) GetParameterWithFallback(ctx context.Context, paramName stri
 	// Fallback to environment variable
 	envValue := os.Getenv(paramName)
 	if envValue != "" {
-		fmt.Printf("Warning: Using environment variable %s as fallback (Parameter Store: %v)\n", paramName, err)
+		logging.FromContext(ctx).Warn("using environment variable as fallback", "name", paramName, logging.KeyError, err)
 		return envValue, nil
 	}
 
@@ -0,0 +1,99 @@
+// Package ical writes calendar events as an iCalendar (RFC 5545) feed
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"schedule-reminder/internal/domain/model"
+)
+
+const (
+	productID = "-//schedule-reminder//Notion reminders//JA"
+
+	// maxLineOctets is the longest content line before folding, excluding the CRLF
+	maxLineOctets = 75
+
+	utcLayout  = "20060102T150405Z"
+	dateLayout = "20060102"
+)
+
+// Encode writes events as a VCALENDAR named name. stamp is the DTSTAMP of every
+// event, normally the time the feed is generated.
+func Encode(w io.Writer, name string, events []model.CalendarEvent, stamp time.Time) error {
+	bw := bufio.NewWriter(w)
+	line := func(format string, args ...any) {
+		writeFolded(bw, fmt.Sprintf(format, args...))
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:%s", productID)
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	line("X-WR-CALNAME:%s", escapeText(name))
+
+	for _, event := range events {
+		line("BEGIN:VEVENT")
+		line("UID:%s", event.UID)
+		line("DTSTAMP:%s", stamp.UTC().Format(utcLayout))
+		if event.AllDay {
+			line("DTSTART;VALUE=DATE:%s", event.Start.Format(dateLayout))
+			line("DTEND;VALUE=DATE:%s", event.Start.AddDate(0, 0, 1).Format(dateLayout))
+		} else {
+			line("DTSTART:%s", event.Start.UTC().Format(utcLayout))
+		}
+		line("SUMMARY:%s", escapeText(event.Summary))
+		if event.Description != "" {
+			line("DESCRIPTION:%s", escapeText(event.Description))
+		}
+		if event.URL != "" {
+			line("URL:%s", event.URL)
+		}
+		for _, alarm := range event.Alarms {
+			line("BEGIN:VALARM")
+			line("ACTION:DISPLAY")
+			line("TRIGGER;VALUE=DATE-TIME:%s", alarm.Trigger.UTC().Format(utcLayout))
+			line("DESCRIPTION:%s", escapeText(alarm.Description))
+			line("END:VALARM")
+		}
+		line("END:VEVENT")
+	}
+
+	line("END:VCALENDAR")
+	return bw.Flush()
+}
+
+// escapeText escapes a TEXT value (RFC 5545 3.3.11)
+func escapeText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// writeFolded writes one content line, folding it into lines of at most
+// maxLineOctets octets without splitting a UTF-8 character (RFC 5545 3.1)
+func writeFolded(w *bufio.Writer, s string) {
+	limit := maxLineOctets
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		w.WriteString(s[:cut])
+		w.WriteString("\r\n ")
+		s = s[cut:]
+		// Continuation lines start with a space, which counts towards the limit
+		limit = maxLineOctets - 1
+	}
+	w.WriteString(s)
+	w.WriteString("\r\n")
+}
@@ -0,0 +1,77 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"schedule-reminder/internal/domain/model"
+)
+
+func TestEncode(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	events := []model.CalendarEvent{
+		{
+			UID:         "page1-20261020@schedule-reminder",
+			Summary:     "定例会; 第3回, 本社",
+			Description: "議題\n週次: 1日前",
+			URL:         "https://www.notion.so/page1",
+			Start:       time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC),
+			AllDay:      true,
+			Alarms: []model.CalendarAlarm{
+				{Trigger: time.Date(2026, 10, 19, 9, 0, 0, 0, jst), Description: "定例会 (1日前)"},
+			},
+		},
+		{UID: "page2-20261021@schedule-reminder", Summary: "請求書送付", Start: time.Date(2026, 10, 21, 15, 30, 0, 0, jst)},
+	}
+
+	var buf bytes.Buffer
+	stamp := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	if err := Encode(&buf, "リマインダー", events, stamp); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+		"DTSTAMP:20261019T000000Z\r\n",
+		"DTSTART;VALUE=DATE:20261020\r\nDTEND;VALUE=DATE:20261021\r\n",
+		`SUMMARY:定例会\; 第3回\, 本社` + "\r\n",
+		`DESCRIPTION:議題\n週次: 1日前` + "\r\n",
+		"BEGIN:VALARM\r\nACTION:DISPLAY\r\nTRIGGER;VALUE=DATE-TIME:20261019T000000Z\r\n",
+		"DTSTART:20261021T063000Z\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output does not contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "BEGIN:VEVENT") != 2 {
+		t.Errorf("expected 2 events:\n%s", out)
+	}
+}
+
+func TestEncodeFoldsLongLines(t *testing.T) {
+	events := []model.CalendarEvent{{UID: "x", Summary: strings.Repeat("あ", 40), Start: time.Now()}}
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, "name", events, time.Now()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var unfolded strings.Builder
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n") {
+		if len(line) > maxLineOctets {
+			t.Errorf("line of %d octets: %q", len(line), line)
+		}
+		if strings.HasPrefix(line, " ") {
+			unfolded.WriteString(line[1:])
+			continue
+		}
+		unfolded.WriteString("\n" + line)
+	}
+	if !strings.Contains(unfolded.String(), "SUMMARY:"+strings.Repeat("あ", 40)) {
+		t.Errorf("folded summary does not round-trip:\n%s", buf.String())
+	}
+}
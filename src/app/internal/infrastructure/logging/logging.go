@@ -0,0 +1,67 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// Attribute keys shared by all log lines, so that CloudWatch Logs Insights can
+// filter one run, configuration or schedule
+const (
+	KeyRequestID  = "requestId"
+	KeyConfigID   = "configId"
+	KeyConfigName = "configName"
+	KeyScheduleID = "scheduleId"
+	KeySchedule   = "schedule"
+	KeyTiming     = "timing"
+	KeyChannel    = "channel"
+	KeyError      = "error"
+)
+
+type contextKey struct{}
+
+// New creates a JSON logger writing to w at the level selected by the DEBUG env var
+func New(w io.Writer) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: LevelFromEnv()}))
+}
+
+// LevelFromEnv returns the debug level when the DEBUG flag is set, and the info level otherwise
+func LevelFromEnv() slog.Level {
+	if EnvFlag("DEBUG") {
+		return slog.LevelDebug
+	}
+	return slog.LevelInfo
+}
+
+// EnvFlag reports whether the env var is set to anything other than "", "0" or "false"
+func EnvFlag(name string) bool {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv(name))) {
+	case "", "0", "false":
+		return false
+	default:
+		return true
+	}
+}
+
+// NewContext returns a copy of ctx carrying logger
+func NewContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// FromContext returns the logger carried by ctx, or the default logger
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(contextKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// With adds attributes to the logger carried by ctx and returns both the new
+// context and the logger, so that everything called with the context logs them too
+func With(ctx context.Context, args ...any) (context.Context, *slog.Logger) {
+	logger := FromContext(ctx).With(args...)
+	return NewContext(ctx, logger), logger
+}
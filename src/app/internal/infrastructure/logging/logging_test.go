@@ -0,0 +1,56 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func TestLevelFromEnv(t *testing.T) {
+	tests := []struct {
+		value string
+		want  slog.Level
+	}{
+		{"", slog.LevelInfo},
+		{"0", slog.LevelInfo},
+		{"false", slog.LevelInfo},
+		{"1", slog.LevelDebug},
+		{"true", slog.LevelDebug},
+	}
+
+	for _, tt := range tests {
+		t.Setenv("DEBUG", tt.value)
+		if got := LevelFromEnv(); got != tt.want {
+			t.Errorf("DEBUG=%q: got %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestWith(t *testing.T) {
+	t.Setenv("DEBUG", "")
+	var buf bytes.Buffer
+	ctx := NewContext(context.Background(), New(&buf))
+
+	ctx, _ = With(ctx, KeyRequestID, "req-1")
+	ctx, logger := With(ctx, KeyConfigID, "cfg-1")
+	logger.Debug("hidden")
+	FromContext(ctx).Info("sent", KeyChannel, "Slack")
+
+	var line map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatalf("expected one JSON line, got %q: %v", buf.String(), err)
+	}
+	for key, want := range map[string]string{"msg": "sent", "level": "INFO", KeyRequestID: "req-1", KeyConfigID: "cfg-1", KeyChannel: "Slack"} {
+		if line[key] != want {
+			t.Errorf("%s: got %v, want %q", key, line[key], want)
+		}
+	}
+}
+
+func TestFromContextDefault(t *testing.T) {
+	if FromContext(context.Background()) != slog.Default() {
+		t.Error("expected the default logger without a logger in the context")
+	}
+}
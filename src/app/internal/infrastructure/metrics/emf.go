@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"schedule-reminder/internal/domain/model"
+	"schedule-reminder/internal/infrastructure/logging"
+	"sort"
+	"sync"
+	"time"
+)
+
+// EMFSink writes metrics as CloudWatch Embedded Metric Format log lines.
+// CloudWatch Logs extracts them into metrics, so no API calls are made.
+type EMFSink struct {
+	mu        sync.Mutex
+	w         io.Writer
+	namespace string
+	now       func() time.Time
+}
+
+// NewEMFSink creates a sink writing one EMF document per metric to w
+func NewEMFSink(w io.Writer, namespace string) *EMFSink {
+	return &EMFSink{w: w, namespace: namespace, now: time.Now}
+}
+
+type emfMetadata struct {
+	Timestamp         int64          `json:"Timestamp"`
+	CloudWatchMetrics []emfDirective `json:"CloudWatchMetrics"`
+}
+
+type emfDirective struct {
+	Namespace  string                `json:"Namespace"`
+	Dimensions [][]string            `json:"Dimensions"`
+	Metrics    []emfMetricDefinition `json:"Metrics"`
+}
+
+type emfMetricDefinition struct {
+	Name string `json:"Name"`
+	Unit string `json:"Unit"`
+}
+
+// Record writes the metric. Write errors are logged and otherwise ignored so
+// that metrics never fail a run.
+func (s *EMFSink) Record(ctx context.Context, metric model.Metric) {
+	keys := make([]string, 0, len(metric.Dimensions))
+	for key := range metric.Dimensions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// Dimension values and the metric value are top-level members of the document
+	document := make(map[string]interface{}, len(keys)+2)
+	for _, key := range keys {
+		document[key] = metric.Dimensions[key]
+	}
+	document[metric.Name] = metric.Value
+	document["_aws"] = emfMetadata{
+		Timestamp: s.now().UnixMilli(),
+		CloudWatchMetrics: []emfDirective{{
+			Namespace:  s.namespace,
+			Dimensions: [][]string{keys},
+			Metrics:    []emfMetricDefinition{{Name: metric.Name, Unit: metric.Unit}},
+		}},
+	}
+
+	data, err := json.Marshal(document)
+	if err != nil {
+		logging.FromContext(ctx).Warn("failed to marshal metric", "metric", metric.Name, logging.KeyError, err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.w.Write(append(data, '\n')); err != nil {
+		logging.FromContext(ctx).Warn("failed to write metric", "metric", metric.Name, logging.KeyError, err)
+	}
+}
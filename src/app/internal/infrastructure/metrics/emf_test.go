@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"schedule-reminder/internal/domain/model"
+	"testing"
+	"time"
+)
+
+func TestEMFSinkRecord(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewEMFSink(&buf, "ScheduleReminder")
+	sink.now = func() time.Time { return time.UnixMilli(1700000000000) }
+
+	sink.Record(context.Background(), model.Metric{
+		Name:       "NotificationsSent",
+		Unit:       model.UnitCount,
+		Value:      1,
+		Dimensions: map[string]string{"Config": "週次", "Channel": "slack"},
+	})
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("expected one JSON line, got %q: %v", buf.String(), err)
+	}
+	want := map[string]interface{}{
+		"Config":            "週次",
+		"Channel":           "slack",
+		"NotificationsSent": float64(1),
+		"_aws": map[string]interface{}{
+			"Timestamp": float64(1700000000000),
+			"CloudWatchMetrics": []interface{}{map[string]interface{}{
+				"Namespace":  "ScheduleReminder",
+				"Dimensions": []interface{}{[]interface{}{"Channel", "Config"}},
+				"Metrics":    []interface{}{map[string]interface{}{"Name": "NotificationsSent", "Unit": "Count"}},
+			}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v\nwant %v", got, want)
+	}
+}
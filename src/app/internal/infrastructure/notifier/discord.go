@@ -26,32 +26,48 @@ func NewDiscordNotifier(webhookURL string) *DiscordNotifier {
 	}
 }
 
-// Send sends a notification to Discord
+// Send sends a notification to Discord.
+// Messages over Discord's 2000 character limit are split into consecutive posts.
 func (d *DiscordNotifier) Send(ctx context.Context, notification *model.Notification) error {
+	return sendChunks(ctx, notification, splitMessage(notification.Message, discordMaxLength), d.post)
+}
+
+func (d *DiscordNotifier) post(ctx context.Context, content string) error {
 	payload := map[string]interface{}{
-		"content": notification.Message,
+		"content": content,
 	}
 
 	jsonData, err := json.Marshal(payload)
 	if err != nil {
-		return fmt.Errorf("failed to marshal payload: %w", err)
+		return permanentError(fmt.Errorf("failed to marshal payload: %w", err))
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "POST", d.webhookURL, bytes.NewBuffer(jsonData))
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return permanentError(fmt.Errorf("failed to create request: %w", err))
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := d.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to send request: %w", err)
+		return requestError(err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("discord webhook returned status %d", resp.StatusCode)
+		body := readErrorBody(resp)
+		deliveryErr := responseError("discord webhook", resp, body)
+		if resp.StatusCode == http.StatusTooManyRequests {
+			// Discord reports the precise wait in the body, in seconds
+			var rateLimit struct {
+				RetryAfter float64 `json:"retry_after"`
+			}
+			if json.Unmarshal(body, &rateLimit) == nil && rateLimit.RetryAfter > 0 {
+				deliveryErr.RetryAfter = time.Duration(rateLimit.RetryAfter * float64(time.Second))
+			}
+		}
+		return deliveryErr
 	}
 
 	return nil
@@ -0,0 +1,91 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"schedule-reminder/internal/domain/model"
+	"schedule-reminder/internal/infrastructure/retry"
+	"time"
+)
+
+// maxErrorBodySize bounds how much of an error response is read
+const maxErrorBodySize = 4096
+
+// DeliveryError is returned by notifiers when a notification could not be delivered.
+// It tells the caller whether retrying can help and how long the destination asked to wait.
+type DeliveryError struct {
+	StatusCode int           // HTTP status of the response, 0 when none was received
+	RetryAfter time.Duration // Wait requested by the destination, 0 when unspecified
+	Permanent  bool          // Retrying the same request cannot succeed
+	SentChunks int           // Chunks of a split message delivered before the failure, counting earlier attempts
+	Err        error
+}
+
+func (e *DeliveryError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *DeliveryError) Unwrap() error {
+	return e.Err
+}
+
+// permanentError marks an error that no retry can fix, e.g. a missing setting
+func permanentError(err error) error {
+	return &DeliveryError{Permanent: true, Err: err}
+}
+
+// sendChunks posts the chunks a notification has not delivered yet, in order.
+// A failure records how many chunks are delivered, so a retry resumes at the
+// failed chunk instead of posting the earlier ones again.
+func sendChunks(ctx context.Context, notification *model.Notification, chunks []string, post func(context.Context, string) error) error {
+	for i := notification.SentChunks; i < len(chunks); i++ {
+		if err := post(ctx, chunks[i]); err != nil {
+			var deliveryErr *DeliveryError
+			if errors.As(err, &deliveryErr) {
+				deliveryErr.SentChunks = i
+				return err
+			}
+			return &DeliveryError{SentChunks: i, Err: err}
+		}
+	}
+	return nil
+}
+
+// requestError wraps a failure to get any response, which is worth retrying
+func requestError(err error) error {
+	return &DeliveryError{Err: fmt.Errorf("failed to send request: %w", err)}
+}
+
+// responseError builds the error for a non-2xx response from service.
+// Client errors are permanent except timeouts and rate limits.
+func responseError(service string, resp *http.Response, body []byte) *DeliveryError {
+	err := &DeliveryError{
+		StatusCode: resp.StatusCode,
+		Permanent:  isPermanentStatus(resp.StatusCode),
+		Err:        fmt.Errorf("%s returned status %d", service, resp.StatusCode),
+	}
+	if len(body) > 0 {
+		err.Err = fmt.Errorf("%s returned status %d: %s", service, resp.StatusCode, truncateMessage(string(body), 200))
+	}
+	if retryAfter, ok := retry.ParseRetryAfter(resp.Header.Get("Retry-After")); ok {
+		err.RetryAfter = retryAfter
+	}
+	return err
+}
+
+// readErrorBody reads the start of an error response body
+func readErrorBody(resp *http.Response) []byte {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	return body
+}
+
+func isPermanentStatus(status int) bool {
+	switch status {
+	case http.StatusRequestTimeout, http.StatusTooEarly, http.StatusTooManyRequests:
+		return false
+	}
+	return status >= 400 && status < 500
+}
@@ -0,0 +1,42 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"schedule-reminder/internal/domain/model"
+	"testing"
+	"time"
+)
+
+func TestDiscordDeliveryErrors(t *testing.T) {
+	tests := []struct {
+		status        int
+		body          string
+		wantPermanent bool
+		wantRetry     time.Duration
+	}{
+		{http.StatusTooManyRequests, `{"message":"You are being rate limited.","retry_after":1.5,"global":false}`, false, 1500 * time.Millisecond},
+		{http.StatusNotFound, `{"message":"Unknown Webhook","code":10015}`, true, 0},
+		{http.StatusBadGateway, ``, false, 0},
+	}
+
+	for _, tt := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.status)
+			w.Write([]byte(tt.body))
+		}))
+
+		err := NewDiscordNotifier(server.URL).Send(context.Background(), &model.Notification{Message: "hello"})
+		server.Close()
+
+		var deliveryErr *DeliveryError
+		if !errors.As(err, &deliveryErr) {
+			t.Fatalf("status %d: got %v, want a DeliveryError", tt.status, err)
+		}
+		if deliveryErr.StatusCode != tt.status || deliveryErr.Permanent != tt.wantPermanent || deliveryErr.RetryAfter != tt.wantRetry {
+			t.Fatalf("status %d: got %+v", tt.status, deliveryErr)
+		}
+	}
+}
@@ -2,15 +2,15 @@ package notifier
 
 import (
 	"fmt"
+	"net/url"
 	"schedule-reminder/internal/domain/model"
 	"strings"
 )
 
-// CreateNotifier creates a notifier based on the configuration
-func CreateNotifier(config *model.ReminderConfig) (Notifier, error) {
-	channel := strings.ToLower(config.NotificationChannel)
-
-	switch channel {
+// CreateNotifier creates a notifier for one of the configuration's channels.
+// commenter is only required for the Notion channel.
+func CreateNotifier(config *model.ReminderConfig, channel string, commenter Commenter) (Notifier, error) {
+	switch strings.ToLower(channel) {
 	case "discord":
 		if config.WebhookURL == "" {
 			return nil, fmt.Errorf("webhook URL required for Discord")
@@ -31,8 +31,30 @@ func CreateNotifier(config *model.ReminderConfig) (Notifier, error) {
 			return nil, fmt.Errorf("webhook URL required for Slack")
 		}
 		return NewSlackNotifier(config.WebhookURL), nil
+	case "notion":
+		if commenter == nil {
+			return nil, fmt.Errorf("notion client required for Notion")
+		}
+		return NewNotionNotifier(commenter, config.MentionPropertyName), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported notification channel: %s", channel)
+	}
+}
+
+// CreateWebhookNotifier creates a notifier for a bare webhook URL, such as the
+// operator alert destination. Discord webhooks are detected by host; any other
+// URL is treated as a Slack-compatible incoming webhook.
+func CreateWebhookNotifier(webhookURL string) (Notifier, error) {
+	u, err := url.Parse(webhookURL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid webhook URL")
+	}
 
+	switch strings.ToLower(u.Hostname()) {
+	case "discord.com", "discordapp.com", "canary.discord.com", "ptb.discord.com":
+		return NewDiscordNotifier(webhookURL), nil
 	default:
-		return nil, fmt.Errorf("unsupported notification channel: %s", config.NotificationChannel)
+		return NewSlackNotifier(webhookURL), nil
 	}
 }
@@ -29,29 +29,35 @@ func NewLineNotifier(channelToken string) *LineNotifier {
 }
 
 // Send sends a notification to LINE.
+// Messages over LINE's 5000 character limit are split into up to five text
+// messages of a single push; anything beyond that is truncated.
 func (l *LineNotifier) Send(ctx context.Context, notification *model.Notification) error {
 	if notification.Destination == "" {
-		return fmt.Errorf("line recipient ID is required")
+		return permanentError(fmt.Errorf("line recipient ID is required"))
+	}
+
+	chunks := limitChunks(splitMessage(notification.Message, lineMaxLength), lineMaxMessages, lineMaxLength)
+	messages := make([]map[string]string, 0, len(chunks))
+	for _, chunk := range chunks {
+		messages = append(messages, map[string]string{
+			"type": "text",
+			"text": chunk,
+		})
 	}
 
 	payload := map[string]interface{}{
-		"to": notification.Destination,
-		"messages": []map[string]string{
-			{
-				"type": "text",
-				"text": notification.Message,
-			},
-		},
+		"to":       notification.Destination,
+		"messages": messages,
 	}
 
 	jsonData, err := json.Marshal(payload)
 	if err != nil {
-		return fmt.Errorf("failed to marshal payload: %w", err)
+		return permanentError(fmt.Errorf("failed to marshal payload: %w", err))
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "POST", linePushEndpoint, bytes.NewBuffer(jsonData))
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return permanentError(fmt.Errorf("failed to create request: %w", err))
 	}
 
 	req.Header.Set("Content-Type", "application/json")
@@ -59,12 +65,12 @@ func (l *LineNotifier) Send(ctx context.Context, notification *model.Notificatio
 
 	resp, err := l.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to send request: %w", err)
+		return requestError(err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("line push returned status %d", resp.StatusCode)
+		return responseError("line push", resp, readErrorBody(resp))
 	}
 
 	return nil
@@ -0,0 +1,82 @@
+package notifier
+
+import (
+	"strings"
+	"unicode/utf16"
+)
+
+// Platform message length limits, counted in UTF-16 code units to be safe for emoji
+const (
+	discordMaxLength = 2000
+	slackMaxLength   = 4000 // Slack truncates text beyond this
+	lineMaxLength    = 5000
+	lineMaxMessages  = 5 // Text objects per push request
+)
+
+// truncationMarker is appended when a message has to be cut
+const truncationMarker = "…"
+
+// messageLength returns the length of s in UTF-16 code units
+func messageLength(s string) int {
+	return len(utf16.Encode([]rune(s)))
+}
+
+// splitMessage splits a message into chunks no longer than limit, preferring
+// line breaks, then spaces, and never cutting inside a character
+func splitMessage(message string, limit int) []string {
+	if messageLength(message) <= limit {
+		return []string{message}
+	}
+
+	var chunks []string
+	remaining := message
+	for messageLength(remaining) > limit {
+		cut := prefixWithin(remaining, limit)
+		if i := strings.LastIndex(cut, "\n"); i > 0 {
+			cut = cut[:i+1]
+		} else if i := strings.LastIndex(cut, " "); i > 0 {
+			cut = cut[:i+1]
+		}
+		chunks = append(chunks, strings.TrimRight(cut, "\n"))
+		remaining = remaining[len(cut):]
+	}
+	if remaining != "" {
+		chunks = append(chunks, remaining)
+	}
+	return chunks
+}
+
+// truncateMessage cuts a message to limit, marking the cut
+func truncateMessage(message string, limit int) string {
+	if messageLength(message) <= limit {
+		return message
+	}
+	return prefixWithin(message, limit-messageLength(truncationMarker)) + truncationMarker
+}
+
+// limitChunks keeps at most max chunks, truncating the last one kept
+// with everything that did not fit
+func limitChunks(chunks []string, max, limit int) []string {
+	if len(chunks) <= max {
+		return chunks
+	}
+	kept := append([]string{}, chunks[:max]...)
+	kept[max-1] = truncateMessage(strings.Join(chunks[max-1:], "\n"), limit)
+	return kept
+}
+
+// prefixWithin returns the longest prefix of s whose length is at most limit
+func prefixWithin(s string, limit int) string {
+	length := 0
+	for i, r := range s {
+		size := 1
+		if r >= 0x10000 {
+			size = 2
+		}
+		if length+size > limit {
+			return s[:i]
+		}
+		length += size
+	}
+	return s
+}
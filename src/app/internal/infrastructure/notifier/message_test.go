@@ -0,0 +1,46 @@
+package notifier
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSplitMessage(t *testing.T) {
+	message := strings.Repeat("あ", 8) + "\n" + strings.Repeat("い", 8) + "\n" + "う"
+
+	chunks := splitMessage(message, 9)
+	want := []string{strings.Repeat("あ", 8), strings.Repeat("い", 8), "う"}
+	if len(chunks) != len(want) {
+		t.Fatalf("got %q, want %q", chunks, want)
+	}
+	for i := range want {
+		if chunks[i] != want[i] {
+			t.Fatalf("chunk %d: got %q, want %q", i, chunks[i], want[i])
+		}
+	}
+
+	// Without separators the message is cut at the limit
+	for _, chunk := range splitMessage(strings.Repeat("x", 25), 10) {
+		if messageLength(chunk) > 10 {
+			t.Fatalf("chunk %q exceeds limit", chunk)
+		}
+	}
+}
+
+func TestTruncateMessage(t *testing.T) {
+	// Emoji take two UTF-16 code units and must not be split
+	got := truncateMessage(strings.Repeat("😀", 5), 6)
+	if got != "😀😀"+truncationMarker {
+		t.Fatalf("got %q", got)
+	}
+	if got := truncateMessage("short", 10); got != "short" {
+		t.Fatalf("got %q, want unchanged", got)
+	}
+}
+
+func TestLimitChunks(t *testing.T) {
+	got := limitChunks([]string{"a", "b", "c", "d"}, 2, 10)
+	if len(got) != 2 || got[0] != "a" || got[1] != "b\nc\nd" {
+		t.Fatalf("got %q", got)
+	}
+}
@@ -0,0 +1,66 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"schedule-reminder/internal/domain/model"
+
+	"github.com/jomei/notionapi"
+)
+
+// Commenter creates comments on Notion pages
+type Commenter interface {
+	CreateComment(ctx context.Context, pageID string, message string, mentionUserIDs []string) error
+}
+
+// NotionNotifier sends notifications as comments on the schedule's Notion page.
+type NotionNotifier struct {
+	commenter       Commenter
+	mentionProperty string
+}
+
+// NewNotionNotifier creates a new Notion comment notifier.
+// Members of mentionProperty (a People property) are @mentioned when set.
+func NewNotionNotifier(commenter Commenter, mentionProperty string) *NotionNotifier {
+	return &NotionNotifier{
+		commenter:       commenter,
+		mentionProperty: mentionProperty,
+	}
+}
+
+// Send creates a comment on the schedule page.
+func (n *NotionNotifier) Send(ctx context.Context, notification *model.Notification) error {
+	if notification.Destination == "" {
+		return permanentError(fmt.Errorf("notion page ID is required"))
+	}
+
+	var mentions []string
+	if n.mentionProperty != "" && notification.Schedule != nil {
+		mentions = notification.Schedule.PeopleIDs[n.mentionProperty]
+	}
+
+	if err := n.commenter.CreateComment(ctx, notification.Destination, notification.Message, mentions); err != nil {
+		deliveryErr := &DeliveryError{Err: fmt.Errorf("failed to send request: %w", err)}
+		// The Notion client already retries rate limits and server errors,
+		// so only failures without an API response are worth another attempt
+		var apiErr *notionapi.Error
+		if errors.As(err, &apiErr) {
+			deliveryErr.StatusCode = apiErr.Status
+			deliveryErr.Permanent = true
+		}
+		var rateLimitErr *notionapi.RateLimitedError
+		if errors.As(err, &rateLimitErr) {
+			deliveryErr.StatusCode = http.StatusTooManyRequests
+			deliveryErr.Permanent = true
+		}
+		return deliveryErr
+	}
+	return nil
+}
+
+// Type returns the notifier type.
+func (n *NotionNotifier) Type() string {
+	return "Notion"
+}
@@ -27,31 +27,36 @@ func NewSlackNotifier(webhookURL string) *SlackNotifier {
 }
 
 // Send sends a notification to Slack.
+// Messages over Slack's 4000 character limit are split into consecutive posts.
 func (s *SlackNotifier) Send(ctx context.Context, notification *model.Notification) error {
+	return sendChunks(ctx, notification, splitMessage(notification.Message, slackMaxLength), s.post)
+}
+
+func (s *SlackNotifier) post(ctx context.Context, text string) error {
 	payload := map[string]string{
-		"text": notification.Message,
+		"text": text,
 	}
 
 	jsonData, err := json.Marshal(payload)
 	if err != nil {
-		return fmt.Errorf("failed to marshal payload: %w", err)
+		return permanentError(fmt.Errorf("failed to marshal payload: %w", err))
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "POST", s.webhookURL, bytes.NewBuffer(jsonData))
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return permanentError(fmt.Errorf("failed to create request: %w", err))
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to send request: %w", err)
+		return requestError(err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("slack webhook returned status %d", resp.StatusCode)
+		return responseError("slack webhook", resp, readErrorBody(resp))
 	}
 
 	return nil
@@ -1,33 +1,75 @@
 package notion
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
 	"schedule-reminder/internal/domain/model"
+	"schedule-reminder/internal/infrastructure/logging"
+	"schedule-reminder/internal/infrastructure/tracing"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/jomei/notionapi"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	// notionAPIBaseURL is the root of the requests made without notionapi
+	notionAPIBaseURL = "https://api.notion.com/v1/"
+
+	// notionVersion matches the API version notionapi requests
+	notionVersion = "2022-06-28"
 )
 
 // Client wraps the Notion API client
 type Client struct {
 	client *notionapi.Client
+
+	// Requests whose raw response notionapi does not expose
+	httpClient *http.Client
+	apiKey     string
+	baseURL    string
+
+	titleMu    sync.Mutex
+	pageTitles map[notionapi.PageID]string // Relation targets resolved during this run
+
+	schemaMu sync.Mutex
+	schemas  map[string]*notionapi.Database // Target database schemas fetched during this run
 }
 
-// NewClient creates a new Notion client
+// NewClient creates a new Notion client.
+// Requests are throttled by the shared rate limiter and retried on 429 and 5xx responses.
 func NewClient(apiKey string) *Client {
+	httpClient := &http.Client{Transport: NewRetryTransport(http.DefaultTransport, sharedLimiter)}
 	return &Client{
-		client: notionapi.NewClient(notionapi.Token(apiKey)),
+		client: notionapi.NewClient(notionapi.Token(apiKey),
+			notionapi.WithHTTPClient(httpClient),
+			// Retries happen in the transport; notionapi's own retry cannot replay request bodies
+			notionapi.WithRetry(1),
+		),
+		httpClient: httpClient,
+		apiKey:     apiKey,
+		baseURL:    notionAPIBaseURL,
+		pageTitles: make(map[notionapi.PageID]string),
+		schemas:    make(map[string]*notionapi.Database),
 	}
 }
 
-// LoadReminderConfigs loads all reminder configurations from the master database
-func (c *Client) LoadReminderConfigs(ctx context.Context, masterDBID string) ([]*model.ReminderConfig, error) {
+// LoadReminderConfigs loads all enabled reminder configurations from the master database.
+// Rows that cannot be parsed or are invalid are skipped and returned as load errors.
+func (c *Client) LoadReminderConfigs(ctx context.Context, masterDBID string) (configs []*model.ReminderConfig, loadErrors []*model.ConfigLoadError, err error) {
+	ctx, span := tracing.Start(ctx, "Notion.LoadReminderConfigs", attribute.String("notion.database.id", masterDBID))
+	defer func() { tracing.End(span, err) }()
+
 	enabledProperty, err := c.resolveEnabledProperty(ctx, masterDBID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	query := &notionapi.DatabaseQueryRequest{
@@ -39,23 +81,24 @@ func (c *Client) LoadReminderConfigs(ctx context.Context, masterDBID string) ([]
 		},
 	}
 
-	var configs []*model.ReminderConfig
-	for {
-		result, err := c.client.Database.Query(ctx, notionapi.DatabaseID(masterDBID), query)
+	for pageNumber := 1; ; pageNumber++ {
+		result, err := c.queryDatabase(ctx, masterDBID, query, pageNumber)
 		if err != nil {
-			return nil, fmt.Errorf("failed to query master database with enabled property %q: %w", enabledProperty, err)
+			return nil, nil, fmt.Errorf("failed to query master database with enabled property %q: %w", enabledProperty, err)
 		}
 
 		for _, page := range result.Results {
 			config, err := c.parseReminderConfig(page)
 			if err != nil {
 				// Log error but continue processing other configs
-				fmt.Printf("Warning: failed to parse config %s: %v\n", page.ID, err)
+				logging.FromContext(ctx).Warn("failed to parse config", logging.KeyConfigID, page.ID, logging.KeyError, err)
+				loadErrors = append(loadErrors, configLoadError(page, err))
 				continue
 			}
 
 			if err := config.Validate(); err != nil {
-				fmt.Printf("Warning: invalid config %s: %v\n", page.ID, err)
+				logging.FromContext(ctx).Warn("invalid config", logging.KeyConfigID, page.ID, logging.KeyError, err)
+				loadErrors = append(loadErrors, configLoadError(page, err))
 				continue
 			}
 
@@ -68,7 +111,139 @@ func (c *Client) LoadReminderConfigs(ctx context.Context, masterDBID string) ([]
 		query.StartCursor = result.NextCursor
 	}
 
-	return configs, nil
+	span.SetAttributes(attribute.Int("reminder.configs", len(configs)), attribute.Int("reminder.invalid_configs", len(loadErrors)))
+	return configs, loadErrors, nil
+}
+
+// queryDatabase fetches one page of query results in its own span, so that
+// slow pagination shows up in traces
+func (c *Client) queryDatabase(ctx context.Context, databaseID string, query *notionapi.DatabaseQueryRequest, pageNumber int) (result *queryResult, err error) {
+	ctx, span := tracing.Start(ctx, "Notion.QueryDatabase",
+		attribute.String("notion.database.id", databaseID),
+		attribute.Int("notion.page", pageNumber))
+	defer func() { tracing.End(span, err) }()
+
+	data, err := c.doRaw(ctx, http.MethodPost, fmt.Sprintf("databases/%s/query", databaseID), query)
+	if err != nil {
+		return nil, err
+	}
+	result, err = parseQueryResult(data)
+	if err != nil {
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("notion.results", len(result.Results)), attribute.Bool("notion.has_more", result.HasMore))
+	return result, nil
+}
+
+// queryResult is one page of query results, with the parts of each page's
+// properties that notionapi drops, keyed by page ID
+type queryResult struct {
+	*notionapi.DatabaseQueryResponse
+	Raw map[notionapi.ObjectID]rawProperties
+}
+
+// rawProperties holds what notionapi does not keep of a page's properties
+type rawProperties struct {
+	// DateStarts is the raw "start" of each date property. notionapi parses
+	// "2026-10-20" and "2026-10-20T00:00:00Z" into the same time.
+	DateStarts map[string]string
+
+	// TruncatedRelations are the relation properties with more pages than the
+	// query returned (has_more)
+	TruncatedRelations map[string]bool
+}
+
+func parseQueryResult(data []byte) (*queryResult, error) {
+	var response notionapi.DatabaseQueryResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to decode query response: %w", err)
+	}
+
+	var raw struct {
+		Results []struct {
+			ID         notionapi.ObjectID `json:"id"`
+			Properties map[string]struct {
+				Type notionapi.PropertyType `json:"type"`
+				Date *struct {
+					Start string `json:"start"`
+				} `json:"date"`
+				HasMore bool `json:"has_more"`
+			} `json:"properties"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to decode query response: %w", err)
+	}
+
+	result := &queryResult{DatabaseQueryResponse: &response, Raw: make(map[notionapi.ObjectID]rawProperties)}
+	for _, page := range raw.Results {
+		props := rawProperties{DateStarts: make(map[string]string), TruncatedRelations: make(map[string]bool)}
+		for name, prop := range page.Properties {
+			if prop.Date != nil {
+				props.DateStarts[name] = prop.Date.Start
+			}
+			if prop.Type == notionapi.PropertyTypeRelation && prop.HasMore {
+				props.TruncatedRelations[name] = true
+			}
+		}
+		result.Raw[page.ID] = props
+	}
+	return result, nil
+}
+
+// doRaw sends a request through the throttled HTTP client and returns the raw
+// response body. API errors are returned as *notionapi.Error, like notionapi does.
+func (c *Client) doRaw(ctx context.Context, method, path string, body interface{}) ([]byte, error) {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("Notion-Version", notionVersion)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if res.StatusCode != http.StatusOK {
+		apiErr := &notionapi.Error{}
+		if json.Unmarshal(data, apiErr) == nil && apiErr.Message != "" {
+			return nil, apiErr
+		}
+		return nil, fmt.Errorf("notion returned status %d", res.StatusCode)
+	}
+	return data, nil
+}
+
+// configLoadError describes a master database row that could not be loaded
+func configLoadError(page notionapi.Page, err error) *model.ConfigLoadError {
+	loadErr := &model.ConfigLoadError{
+		PageID: page.ID.String(),
+		URL:    page.URL,
+		Err:    err,
+	}
+	if titleProp := getTitleProperty(page, "名前", "Name"); titleProp != nil {
+		loadErr.Name = plainText(titleProp.Title)
+	}
+	return loadErr
 }
 
 func (c *Client) resolveEnabledProperty(ctx context.Context, masterDBID string) (string, error) {
@@ -119,17 +294,18 @@ func describePropertyConfigs(props notionapi.PropertyConfigs) string {
 // parseReminderConfig extracts configuration from a Notion page
 func (c *Client) parseReminderConfig(page notionapi.Page) (*model.ReminderConfig, error) {
 	config := &model.ReminderConfig{
-		ID: page.ID.String(),
+		ID:  page.ID.String(),
+		URL: page.URL,
 	}
 
 	// Name (Title)
 	if titleProp := getTitleProperty(page, "名前", "Name"); titleProp != nil && len(titleProp.Title) > 0 {
-		config.Name = titleProp.Title[0].PlainText
+		config.Name = plainText(titleProp.Title)
 	}
 
 	// Target Database ID
 	if textProp := getRichTextProperty(page, "対象データベースID", "Target Database ID"); textProp != nil && len(textProp.RichText) > 0 {
-		config.TargetDatabaseID = textProp.RichText[0].PlainText
+		config.TargetDatabaseID = strings.TrimSpace(plainText(textProp.RichText))
 	}
 
 	// Reminder Timings (Multi-select)
@@ -139,9 +315,13 @@ func (c *Client) parseReminderConfig(page notionapi.Page) (*model.ReminderConfig
 		}
 	}
 
-	// Notification Channel (Select)
+	// Notification Channels (Select or Multi-select)
 	if selectProp := getSelectProperty(page, "通知チャネル", "Notification Channel"); selectProp != nil && selectProp.Select.Name != "" {
-		config.NotificationChannel = selectProp.Select.Name
+		config.NotificationChannels = []string{selectProp.Select.Name}
+	} else if multiSelectProp := getMultiSelectProperty(page, "通知チャネル", "Notification Channel"); multiSelectProp != nil {
+		for _, option := range multiSelectProp.MultiSelect {
+			config.NotificationChannels = append(config.NotificationChannels, option.Name)
+		}
 	}
 
 	// Webhook URL
@@ -151,17 +331,58 @@ func (c *Client) parseReminderConfig(page notionapi.Page) (*model.ReminderConfig
 
 	// Channel Token
 	if textProp := getRichTextProperty(page, "チャネルアクセストークン", "Channel Access Token"); textProp != nil && len(textProp.RichText) > 0 {
-		config.ChannelToken = textProp.RichText[0].PlainText
+		config.ChannelToken = strings.TrimSpace(plainText(textProp.RichText))
 	}
 
 	// LINE Recipient ID
 	if textProp := getRichTextProperty(page, "LINE送信先ID", "Line Recipient ID", "LINE Recipient ID"); textProp != nil && len(textProp.RichText) > 0 {
-		config.LineRecipientID = textProp.RichText[0].PlainText
+		config.LineRecipientID = strings.TrimSpace(plainText(textProp.RichText))
+	}
+
+	// Mention Property Name
+	if textProp := getRichTextProperty(page, "メンション対象プロパティ", "Mention Property"); textProp != nil && len(textProp.RichText) > 0 {
+		config.MentionPropertyName = strings.TrimSpace(plainText(textProp.RichText))
 	}
 
 	// Message Template
 	if textProp := getRichTextProperty(page, "メッセージテンプレート", "Message Template"); textProp != nil && len(textProp.RichText) > 0 {
-		config.MessageTemplate = textProp.RichText[0].PlainText
+		config.MessageTemplate = plainText(textProp.RichText)
+	}
+
+	// Per-timing and per-channel Message Templates, e.g. "メッセージテンプレート（当日）", "メッセージテンプレート（Slack）"
+	config.TimingTemplates = make(map[string]string)
+	config.ChannelTemplates = make(map[string]string)
+	for suffix, template := range collectSuffixedTemplates(page, "メッセージテンプレート", "Message Template") {
+		if model.IsNotificationChannel(suffix) {
+			config.ChannelTemplates[strings.ToLower(suffix)] = template
+		} else {
+			config.TimingTemplates[suffix] = template
+		}
+	}
+
+	// Unresolved Placeholder Policy (Select)
+	if selectProp := getSelectProperty(page, "未解決変数", "Unresolved Placeholders"); selectProp != nil && selectProp.Select.Name != "" {
+		config.UnresolvedPlaceholders = parsePlaceholderPolicy(selectProp.Select.Name)
+	}
+
+	// Language (Select)
+	if selectProp := getSelectProperty(page, "言語", "Language"); selectProp != nil && selectProp.Select.Name != "" {
+		config.Language = parseLanguage(selectProp.Select.Name)
+	}
+
+	// Auto Advance (Checkbox)
+	if checkboxProp := getCheckboxProperty(page, "期限自動更新", "Auto Advance Due Date"); checkboxProp != nil {
+		config.AutoAdvanceDueDate = checkboxProp.Checkbox
+	}
+
+	// Advance Only When Done (Checkbox)
+	if checkboxProp := getCheckboxProperty(page, "完了時のみ更新", "Advance Only When Done"); checkboxProp != nil {
+		config.AdvanceOnlyWhenDone = checkboxProp.Checkbox
+	}
+
+	// Record History (Checkbox)
+	if checkboxProp := getCheckboxProperty(page, "リマインド履歴を記録", "Record Reminder History"); checkboxProp != nil {
+		config.RecordHistory = checkboxProp.Checkbox
 	}
 
 	// Date Property Name
@@ -170,6 +391,16 @@ func (c *Client) parseReminderConfig(page notionapi.Page) (*model.ReminderConfig
 	// Title Property Name
 	config.TitlePropertyName = "タイトル" // Fixed
 
+	// Recurrence Property Name
+	config.RecurrencePropertyName = "繰り返し" // Fixed
+
+	// Completion Property Name
+	config.CompletionPropertyName = "完了" // Fixed
+
+	// History Property Names
+	config.LastRemindedPropertyName = "最終リマインド日時" // Fixed
+	config.HistoryPropertyName = "リマインド履歴"        // Fixed
+
 	// Timezone
 	loc, err := time.LoadLocation("Asia/Tokyo")
 	if err != nil {
@@ -180,6 +411,67 @@ func (c *Client) parseReminderConfig(page notionapi.Page) (*model.ReminderConfig
 	return config, nil
 }
 
+// parsePlaceholderPolicy maps the select option to a model policy.
+// Unknown options are passed through so that Validate rejects them.
+func parsePlaceholderPolicy(name string) string {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "残す", "leave":
+		return model.PlaceholderLeave
+	case "空欄", "空にする", "blank":
+		return model.PlaceholderBlank
+	case "エラー", "送信しない", "fail":
+		return model.PlaceholderFail
+	}
+	return name
+}
+
+// parseLanguage maps the select option to a language code.
+// Unknown options are passed through as codes, e.g. "fr".
+func parseLanguage(name string) string {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "日本語", "japanese", "ja":
+		return model.LanguageJapanese
+	case "英語", "english", "en":
+		return model.LanguageEnglish
+	}
+	return name
+}
+
+// getDatabase fetches a database schema, caching it for the lifetime of the client
+func (c *Client) getDatabase(ctx context.Context, databaseID string) (*notionapi.Database, error) {
+	c.schemaMu.Lock()
+	db, ok := c.schemas[databaseID]
+	c.schemaMu.Unlock()
+	if ok {
+		return db, nil
+	}
+
+	db, err := c.client.Database.Get(ctx, notionapi.DatabaseID(databaseID))
+	if err != nil {
+		return nil, err
+	}
+
+	c.schemaMu.Lock()
+	c.schemas[databaseID] = db
+	c.schemaMu.Unlock()
+	return db, nil
+}
+
+// DatabasePropertyNames returns the property names of a database
+func (c *Client) DatabasePropertyNames(ctx context.Context, databaseID string) ([]string, error) {
+	db, err := c.getDatabase(ctx, databaseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch database schema %s: %w", databaseID, err)
+	}
+
+	names := make([]string, 0, len(db.Properties))
+	for name := range db.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
 func getTitleProperty(page notionapi.Page, names ...string) *notionapi.TitleProperty {
 	for _, name := range names {
 		if prop, ok := page.Properties[name].(*notionapi.TitleProperty); ok {
@@ -224,3 +516,12 @@ func getURLProperty(page notionapi.Page, names ...string) *notionapi.URLProperty
 	}
 	return nil
 }
+
+func getCheckboxProperty(page notionapi.Page, names ...string) *notionapi.CheckboxProperty {
+	for _, name := range names {
+		if prop, ok := page.Properties[name].(*notionapi.CheckboxProperty); ok {
+			return prop
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package notion
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jomei/notionapi"
+)
+
+// CreateComment posts a comment on a page, @mentioning the given users first
+func (c *Client) CreateComment(ctx context.Context, pageID string, message string, mentionUserIDs []string) error {
+	var richText []notionapi.RichText
+	for _, userID := range mentionUserIDs {
+		richText = append(richText,
+			notionapi.RichText{
+				Type: notionapi.ObjectType("mention"),
+				Mention: &notionapi.Mention{
+					Type: notionapi.MentionTypeUser,
+					User: &notionapi.User{Object: notionapi.ObjectTypeUser, ID: notionapi.UserID(userID)},
+				},
+			},
+			notionapi.RichText{
+				Type: notionapi.ObjectTypeText,
+				Text: &notionapi.Text{Content: " "},
+			},
+		)
+	}
+
+	// A single text object is limited to 2000 characters
+	for _, chunk := range splitRunes(message, maxRichTextLength) {
+		richText = append(richText, notionapi.RichText{
+			Type: notionapi.ObjectTypeText,
+			Text: &notionapi.Text{Content: chunk},
+		})
+	}
+
+	_, err := c.client.Comment.Create(ctx, &notionapi.CommentCreateRequest{
+		Parent: notionapi.Parent{
+			Type:   notionapi.ParentTypePageID,
+			PageID: notionapi.PageID(pageID),
+		},
+		RichText: richText,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create comment on page %s: %w", pageID, err)
+	}
+	return nil
+}
+
+func splitRunes(value string, size int) []string {
+	runes := []rune(value)
+	chunks := make([]string, 0, len(runes)/size+1)
+	for len(runes) > size {
+		chunks = append(chunks, string(runes[:size]))
+		runes = runes[size:]
+	}
+	return append(chunks, string(runes))
+}
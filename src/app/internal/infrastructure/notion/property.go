@@ -0,0 +1,268 @@
+package notion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"schedule-reminder/internal/infrastructure/logging"
+	"strconv"
+	"time"
+
+	"github.com/jomei/notionapi"
+)
+
+// extractPropertyValue extracts the value from a Notion property.
+// Values are strings, []string, float64 or bool; nil means the property is empty.
+func (c *Client) extractPropertyValue(ctx context.Context, prop notionapi.Property, loc *time.Location) interface{} {
+	switch p := prop.(type) {
+	case *notionapi.TitleProperty:
+		if len(p.Title) > 0 {
+			return plainText(p.Title)
+		}
+	case *notionapi.RichTextProperty:
+		if len(p.RichText) > 0 {
+			return plainText(p.RichText)
+		}
+	case *notionapi.TextProperty:
+		if len(p.Text) > 0 {
+			return plainText(p.Text)
+		}
+	case *notionapi.NumberProperty:
+		return p.Number
+	case *notionapi.SelectProperty:
+		if p.Select.Name != "" {
+			return p.Select.Name
+		}
+	case *notionapi.StatusProperty:
+		if p.Status.Name != "" {
+			return p.Status.Name
+		}
+	case *notionapi.MultiSelectProperty:
+		var values []string
+		for _, opt := range p.MultiSelect {
+			values = append(values, opt.Name)
+		}
+		return values
+	case *notionapi.DateProperty:
+		if value := formatDateObject(p.Date); value != "" {
+			return value
+		}
+	case *notionapi.PeopleProperty:
+		var names []string
+		for _, person := range p.People {
+			if person.Name != "" {
+				names = append(names, person.Name)
+			}
+		}
+		return names
+	case *notionapi.CheckboxProperty:
+		return p.Checkbox
+	case *notionapi.URLProperty:
+		return string(p.URL)
+	case *notionapi.EmailProperty:
+		if p.Email != "" {
+			return p.Email
+		}
+	case *notionapi.PhoneNumberProperty:
+		if p.PhoneNumber != "" {
+			return p.PhoneNumber
+		}
+	case *notionapi.FormulaProperty:
+		if value := formatFormulaValue(p.Formula); value != "" {
+			return value
+		}
+	case *notionapi.RelationProperty:
+		var titles []string
+		for _, relation := range p.Relation {
+			if title := c.lookupPageTitle(ctx, relation.ID); title != "" {
+				titles = append(titles, title)
+			}
+		}
+		return titles
+	case *notionapi.RollupProperty:
+		return c.extractRollupValue(ctx, p.Rollup, loc)
+	case *notionapi.FilesProperty:
+		var names []string
+		for _, file := range p.Files {
+			names = append(names, file.Name)
+		}
+		return names
+	case *notionapi.CreatedTimeProperty:
+		return p.CreatedTime.In(loc).Format("2006-01-02 15:04")
+	case *notionapi.LastEditedTimeProperty:
+		return p.LastEditedTime.In(loc).Format("2006-01-02 15:04")
+	case *notionapi.CreatedByProperty:
+		if p.CreatedBy.Name != "" {
+			return p.CreatedBy.Name
+		}
+	case *notionapi.LastEditedByProperty:
+		if p.LastEditedBy.Name != "" {
+			return p.LastEditedBy.Name
+		}
+	case *notionapi.UniqueIDProperty:
+		return p.UniqueID.String()
+	case *notionapi.VerificationProperty:
+		if p.Verification.State != "" {
+			return string(p.Verification.State)
+		}
+	}
+	return nil
+}
+
+// extractRollupValue renders a rollup according to its result type
+func (c *Client) extractRollupValue(ctx context.Context, rollup notionapi.Rollup, loc *time.Location) interface{} {
+	switch rollup.Type {
+	case notionapi.RollupTypeNumber:
+		return rollup.Number
+	case notionapi.RollupTypeDate:
+		if value := formatDateObject(rollup.Date); value != "" {
+			return value
+		}
+	case notionapi.RollupTypeArray:
+		var values []string
+		for _, item := range rollup.Array {
+			switch v := c.extractPropertyValue(ctx, item, loc).(type) {
+			case nil:
+			case []string:
+				values = append(values, v...)
+			case string:
+				values = append(values, v)
+			case float64:
+				values = append(values, strconv.FormatFloat(v, 'f', -1, 64))
+			default:
+				values = append(values, fmt.Sprint(v))
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// lookupPageTitle returns the title of a related page, caching results for the
+// lifetime of the client so each page is fetched at most once
+func (c *Client) lookupPageTitle(ctx context.Context, id notionapi.PageID) string {
+	c.titleMu.Lock()
+	title, ok := c.pageTitles[id]
+	c.titleMu.Unlock()
+	if ok {
+		return title
+	}
+
+	page, err := c.client.Page.Get(ctx, id)
+	if err != nil {
+		logging.FromContext(ctx).Warn("failed to resolve related page", "pageId", id, logging.KeyError, err)
+		return ""
+	}
+
+	for _, prop := range page.Properties {
+		if tp, ok := prop.(*notionapi.TitleProperty); ok {
+			title = plainText(tp.Title)
+			break
+		}
+	}
+
+	c.titleMu.Lock()
+	c.pageTitles[id] = title
+	c.titleMu.Unlock()
+	return title
+}
+
+// truncatedMarker ends a relation list that could only be read in part
+const truncatedMarker = "…"
+
+// completeRelation fetches every related page of a relation property the query
+// returned in part (Notion includes at most 25). If the fetch fails, the
+// partial property is returned with truncated set.
+func (c *Client) completeRelation(ctx context.Context, pageID notionapi.ObjectID, prop *notionapi.RelationProperty) (result notionapi.Property, truncated bool) {
+	var relations []notionapi.Relation
+	cursor := ""
+	for {
+		path := fmt.Sprintf("pages/%s/properties/%s", pageID, url.PathEscape(string(prop.ID)))
+		if cursor != "" {
+			path += "?start_cursor=" + url.QueryEscape(cursor)
+		}
+		data, err := c.doRaw(ctx, http.MethodGet, path, nil)
+		if err == nil {
+			var page relationPage
+			if err = json.Unmarshal(data, &page); err == nil {
+				for _, item := range page.Results {
+					relations = append(relations, item.Relation)
+				}
+				if page.HasMore && page.NextCursor != "" {
+					cursor = page.NextCursor
+					continue
+				}
+			}
+		}
+		if err != nil {
+			logging.FromContext(ctx).Warn("failed to fetch all related pages", "pageId", pageID, "property", prop.ID, logging.KeyError, err)
+			return prop, true
+		}
+		break
+	}
+
+	complete := *prop
+	complete.Relation = relations
+	return &complete, false
+}
+
+// relationPage is one page of the page property item endpoint for a relation
+type relationPage struct {
+	Results []struct {
+		Relation notionapi.Relation `json:"relation"`
+	} `json:"results"`
+	HasMore    bool   `json:"has_more"`
+	NextCursor string `json:"next_cursor"`
+}
+
+// formatDateObject formats a date as "2006-01-02", or "start → end" for ranges
+func formatDateObject(date *notionapi.DateObject) string {
+	if date == nil || date.Start == nil {
+		return ""
+	}
+	value := time.Time(*date.Start).Format("2006-01-02")
+	if date.End != nil {
+		value += " → " + time.Time(*date.End).Format("2006-01-02")
+	}
+	return value
+}
+
+// templateSuffixPattern matches "（当日）" or "(当日)" at the end of a property name
+var templateSuffixPattern = regexp.MustCompile(`^(.+?)\s*[（(]([^（）()]+)[）)]$`)
+
+// collectSuffixedTemplates gathers templates from properties named
+// "<base>（<suffix>）" or "<base>(<suffix>)", keyed by suffix (a timing or a channel).
+// Both rich text and string formula properties are read.
+func collectSuffixedTemplates(page notionapi.Page, bases ...string) map[string]string {
+	templates := make(map[string]string)
+	for name, prop := range page.Properties {
+		match := templateSuffixPattern.FindStringSubmatch(name)
+		if len(match) != 3 || !containsString(bases, match[1]) {
+			continue
+		}
+
+		var value string
+		switch p := prop.(type) {
+		case *notionapi.RichTextProperty:
+			value = plainText(p.RichText)
+		case *notionapi.FormulaProperty:
+			value = formatFormulaValue(p.Formula)
+		}
+		if value != "" {
+			templates[match[2]] = value
+		}
+	}
+	return templates
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
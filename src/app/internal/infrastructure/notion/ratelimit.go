@@ -0,0 +1,183 @@
+package notion
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"schedule-reminder/internal/infrastructure/logging"
+	"schedule-reminder/internal/infrastructure/retry"
+	"sync"
+	"time"
+)
+
+// Notion allows an average of three requests per second per integration
+const (
+	notionRequestsPerSecond = 3
+	notionBurst             = 3
+
+	notionMaxAttempts = 5
+	notionBaseDelay   = 500 * time.Millisecond
+	notionMaxDelay    = 10 * time.Second
+)
+
+// sharedLimiter throttles every Client created by NewClient, since they share the integration's quota
+var sharedLimiter = NewRateLimiter(notionRequestsPerSecond, notionBurst)
+
+// RateLimiter is a token bucket shared by concurrent callers
+type RateLimiter struct {
+	mu           sync.Mutex
+	rate         float64 // Tokens added per second
+	burst        float64
+	tokens       float64
+	last         time.Time
+	blockedUntil time.Time // Set by Pause after a 429
+}
+
+// NewRateLimiter creates a limiter allowing rate requests per second with bursts of up to burst
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	return &RateLimiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a request may be made or the context is done
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		delay := l.reserve()
+		if delay == 0 {
+			return nil
+		}
+		if !retry.Sleep(ctx, delay) {
+			return ctx.Err()
+		}
+	}
+}
+
+// Pause stops all callers from making requests for d, e.g. after a 429
+func (l *RateLimiter) Pause(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if until := time.Now().Add(d); until.After(l.blockedUntil) {
+		l.blockedUntil = until
+	}
+}
+
+// reserve takes a token and returns 0, or returns how long to wait before trying again
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if now.Before(l.blockedUntil) {
+		return l.blockedUntil.Sub(now)
+	}
+
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+// retryTransport is an http.RoundTripper that throttles requests through a
+// RateLimiter and retries 429 responses (honouring Retry-After) and 5xx
+// responses (with jittered exponential backoff)
+type retryTransport struct {
+	base        http.RoundTripper
+	limiter     *RateLimiter
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+}
+
+// NewRetryTransport wraps base with rate limiting and retries for the Notion API
+func NewRetryTransport(base http.RoundTripper, limiter *RateLimiter) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &retryTransport{
+		base:        base,
+		limiter:     limiter,
+		maxAttempts: notionMaxAttempts,
+		baseDelay:   notionBaseDelay,
+		maxDelay:    notionMaxDelay,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		if err := t.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		attemptReq, err := rewindRequest(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := t.base.RoundTrip(attemptReq)
+		if err != nil {
+			return nil, err
+		}
+
+		delay, retryable := t.retryDelay(res, attempt)
+		// A body that cannot be replayed makes the request single-shot
+		if !retryable || attempt == t.maxAttempts || (req.Body != nil && req.GetBody == nil) {
+			return res, nil
+		}
+		if res.StatusCode == http.StatusTooManyRequests {
+			t.limiter.Pause(delay)
+		}
+
+		// Drain so the connection can be reused
+		io.Copy(io.Discard, res.Body)
+		res.Body.Close()
+
+		logging.FromContext(ctx).Warn("Notion API request failed, retrying",
+			"status", res.StatusCode, "method", req.Method, "path", req.URL.Path,
+			"delay", delay.String(), "attempt", attempt+1, "maxAttempts", t.maxAttempts)
+		if !retry.Sleep(ctx, delay) {
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// retryDelay reports whether a response should be retried and after how long
+func (t *retryTransport) retryDelay(res *http.Response, attempt int) (time.Duration, bool) {
+	switch {
+	case res.StatusCode == http.StatusTooManyRequests:
+		if delay, ok := retry.ParseRetryAfter(res.Header.Get("Retry-After")); ok {
+			return delay, true
+		}
+		return retry.Backoff(attempt, t.baseDelay, t.maxDelay), true
+	case res.StatusCode >= 500:
+		return retry.Backoff(attempt, t.baseDelay, t.maxDelay), true
+	}
+	return 0, false
+}
+
+// rewindRequest returns the request to send for an attempt, with a fresh body on retries
+func rewindRequest(req *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 1 || req.Body == nil || req.GetBody == nil {
+		return req, nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, fmt.Errorf("failed to rewind request body: %w", err)
+	}
+	clone := req.Clone(req.Context())
+	clone.Body = body
+	return clone, nil
+}
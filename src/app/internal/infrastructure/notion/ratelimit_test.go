@@ -0,0 +1,81 @@
+package notion
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetryTransportRetriesRateLimitAndServerErrors(t *testing.T) {
+	statuses := []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusOK}
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		status := statuses[len(bodies)-1]
+		if status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "0")
+		}
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &retryTransport{
+		base:        http.DefaultTransport,
+		limiter:     NewRateLimiter(1000, 10),
+		maxAttempts: 5,
+		baseDelay:   time.Millisecond,
+		maxDelay:    10 * time.Millisecond,
+	}}
+
+	res, err := client.Post(server.URL, "application/json", strings.NewReader(`{"page_size":1}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("got status %d, want 200", res.StatusCode)
+	}
+	if len(bodies) != 3 {
+		t.Fatalf("got %d attempts, want 3", len(bodies))
+	}
+	for i, body := range bodies {
+		if body != `{"page_size":1}` {
+			t.Fatalf("attempt %d sent body %q", i+1, body)
+		}
+	}
+}
+
+func TestRetryTransportDoesNotRetryClientErrors(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewRetryTransport(nil, NewRateLimiter(1000, 10))}
+	res, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	res.Body.Close()
+
+	if attempts != 1 {
+		t.Fatalf("got %d attempts, want 1", attempts)
+	}
+}
+
+func TestRateLimiterWaitsForTokens(t *testing.T) {
+	limiter := NewRateLimiter(20, 1)
+	if delay := limiter.reserve(); delay != 0 {
+		t.Fatalf("first request delayed by %s", delay)
+	}
+	if delay := limiter.reserve(); delay <= 0 || delay > 50*time.Millisecond {
+		t.Fatalf("second request delayed by %s, want (0, 50ms]", delay)
+	}
+}
@@ -0,0 +1,40 @@
+package notion
+
+import (
+	"schedule-reminder/internal/domain/model"
+	"strings"
+
+	"github.com/jomei/notionapi"
+)
+
+// plainText concatenates every segment of a rich text value
+func plainText(richText []notionapi.RichText) string {
+	var builder strings.Builder
+	for _, rt := range richText {
+		builder.WriteString(rt.PlainText)
+	}
+	return builder.String()
+}
+
+// toRichText converts Notion rich text, keeping annotations and links
+func toRichText(richText []notionapi.RichText) model.RichText {
+	spans := make(model.RichText, 0, len(richText))
+	for _, rt := range richText {
+		span := model.TextSpan{
+			Text: rt.PlainText,
+			Link: rt.Href,
+		}
+		if span.Link == "" && rt.Text != nil && rt.Text.Link != nil {
+			span.Link = rt.Text.Link.Url
+		}
+		if rt.Annotations != nil {
+			span.Bold = rt.Annotations.Bold
+			span.Italic = rt.Annotations.Italic
+			span.Strikethrough = rt.Annotations.Strikethrough
+			span.Underline = rt.Annotations.Underline
+			span.Code = rt.Annotations.Code
+		}
+		spans = append(spans, span)
+	}
+	return spans
+}
@@ -4,22 +4,42 @@ import (
 	"context"
 	"fmt"
 	"schedule-reminder/internal/domain/model"
+	"schedule-reminder/internal/infrastructure/logging"
+	"schedule-reminder/internal/infrastructure/tracing"
+	"strings"
 	"time"
 
 	"github.com/jomei/notionapi"
+	"go.opentelemetry.io/otel/attribute"
 )
 
-// FetchSchedules fetches schedules from a child database
-func (c *Client) FetchSchedules(ctx context.Context, config *model.ReminderConfig, today time.Time) ([]*model.Schedule, error) {
-	// Query only future schedules (optimization)
-	start := notionapi.Date(today)
-	query := &notionapi.DatabaseQueryRequest{
-		Filter: &notionapi.PropertyFilter{
-			Property: config.DatePropertyName,
-			Date: &notionapi.DateFilterCondition{
-				OnOrAfter: &start,
-			},
+// FetchSchedules fetches schedules due on or after since from a child database
+func (c *Client) FetchSchedules(ctx context.Context, config *model.ReminderConfig, since time.Time) (schedules []*model.Schedule, err error) {
+	ctx, span := tracing.Start(ctx, "Notion.FetchSchedules",
+		tracing.KeyConfigID.String(config.ID),
+		attribute.String("notion.database.id", config.TargetDatabaseID))
+	defer func() { tracing.End(span, err) }()
+
+	recurrenceFilter, err := c.resolveRecurrenceFilter(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	// Query only schedules due on or after since (optimization)
+	start := notionapi.Date(since)
+	var filter notionapi.Filter = &notionapi.PropertyFilter{
+		Property: config.DatePropertyName,
+		Date: &notionapi.DateFilterCondition{
+			OnOrAfter: &start,
 		},
+	}
+	if recurrenceFilter != nil {
+		// Recurring schedules keep their first due date, which may be in the past
+		filter = notionapi.OrCompoundFilter{filter, recurrenceFilter}
+	}
+
+	query := &notionapi.DatabaseQueryRequest{
+		Filter: filter,
 		Sorts: []notionapi.SortObject{
 			{
 				Property:  config.DatePropertyName,
@@ -28,22 +48,21 @@ func (c *Client) FetchSchedules(ctx context.Context, config *model.ReminderConfi
 		},
 	}
 
-	var schedules []*model.Schedule
-	for {
-		result, err := c.client.Database.Query(ctx, notionapi.DatabaseID(config.TargetDatabaseID), query)
+	for pageNumber := 1; ; pageNumber++ {
+		result, err := c.queryDatabase(ctx, config.TargetDatabaseID, query, pageNumber)
 		if err != nil {
 			return nil, fmt.Errorf("failed to query database %s: %w", config.TargetDatabaseID, err)
 		}
 
 		for _, page := range result.Results {
-			schedule, err := c.parseSchedule(page, config)
+			schedule, err := c.parseSchedule(ctx, page, config, result.Raw[page.ID])
 			if err != nil {
-				fmt.Printf("Warning: failed to parse schedule %s: %v\n", page.ID, err)
+				logging.FromContext(ctx).Warn("failed to parse schedule", logging.KeyScheduleID, page.ID, logging.KeyError, err)
 				continue
 			}
 
 			if err := schedule.Validate(); err != nil {
-				fmt.Printf("Warning: invalid schedule %s: %v\n", page.ID, err)
+				logging.FromContext(ctx).Warn("invalid schedule", logging.KeyScheduleID, page.ID, logging.KeyError, err)
 				continue
 			}
 
@@ -56,22 +75,25 @@ func (c *Client) FetchSchedules(ctx context.Context, config *model.ReminderConfi
 		query.StartCursor = result.NextCursor
 	}
 
+	span.SetAttributes(attribute.Int("reminder.schedules", len(schedules)))
 	return schedules, nil
 }
 
-// parseSchedule extracts schedule information from a Notion page
-func (c *Client) parseSchedule(page notionapi.Page, config *model.ReminderConfig) (*model.Schedule, error) {
+// parseSchedule extracts schedule information from a Notion page.
+// raw carries the property details that notionapi drops.
+func (c *Client) parseSchedule(ctx context.Context, page notionapi.Page, config *model.ReminderConfig, raw rawProperties) (*model.Schedule, error) {
 	schedule := &model.Schedule{
 		ID:         page.ID.String(),
 		NotionURL:  page.URL,
 		Properties: make(map[string]interface{}),
+		RichText:   make(map[string]model.RichText),
 	}
 
 	// Extract title
 	titleProp := page.Properties[config.TitlePropertyName]
 	if titleProp != nil {
 		if tp, ok := titleProp.(*notionapi.TitleProperty); ok && len(tp.Title) > 0 {
-			schedule.Title = tp.Title[0].PlainText
+			schedule.Title = plainText(tp.Title)
 		}
 	}
 
@@ -80,12 +102,13 @@ func (c *Client) parseSchedule(page notionapi.Page, config *model.ReminderConfig
 	if dateProp != nil {
 		if dp, ok := dateProp.(*notionapi.DateProperty); ok && dp.Date != nil && dp.Date.Start != nil {
 			schedule.DueDate = time.Time(*dp.Date.Start)
+			schedule.AllDay = isDateOnly(raw.DateStarts[config.DatePropertyName])
 		}
 	}
 
 	// Extract description (optional)
 	if descProp := getScheduleRichTextProperty(page, "説明", "Description"); descProp != nil && len(descProp.RichText) > 0 {
-		schedule.Description = descProp.RichText[0].PlainText
+		schedule.Description = plainText(descProp.RichText)
 	}
 	// Extract reminder message (optional)
 	if formulaProp := getScheduleFormulaProperty(page, "リマインドメッセージ"); formulaProp != nil {
@@ -93,7 +116,19 @@ func (c *Client) parseSchedule(page notionapi.Page, config *model.ReminderConfig
 			schedule.MessageTemplate = value
 		}
 	} else if textProp := getScheduleRichTextProperty(page, "リマインドメッセージ"); textProp != nil && len(textProp.RichText) > 0 {
-		schedule.MessageTemplate = textProp.RichText[0].PlainText
+		schedule.MessageTemplate = plainText(textProp.RichText)
+	}
+	// Extract per-timing reminder messages, e.g. "リマインドメッセージ（当日）" (optional)
+	schedule.TimingTemplates = collectSuffixedTemplates(page, "リマインドメッセージ")
+	// Extract recurrence rule (optional)
+	if textProp := getScheduleRichTextProperty(page, config.RecurrencePropertyName, "Recurrence"); textProp != nil && len(textProp.RichText) > 0 {
+		schedule.Recurrence = plainText(textProp.RichText)
+	} else if selectProp := getScheduleSelectProperty(page, config.RecurrencePropertyName, "Recurrence"); selectProp != nil {
+		schedule.Recurrence = selectProp.Select.Name
+	}
+	// Extract completion (optional)
+	if checkboxProp, ok := page.Properties[config.CompletionPropertyName].(*notionapi.CheckboxProperty); ok {
+		schedule.Completed = checkboxProp.Checkbox
 	}
 	// Extract reminder timings (optional)
 	if multiSelectProp := getScheduleMultiSelectProperty(page, "リマインドタイミング", "Reminder Timings"); multiSelectProp != nil {
@@ -104,12 +139,75 @@ func (c *Client) parseSchedule(page notionapi.Page, config *model.ReminderConfig
 
 	// Store all properties for template rendering
 	for key, prop := range page.Properties {
-		schedule.Properties[key] = extractPropertyValue(prop)
+		truncated := false
+		if relationProp, ok := prop.(*notionapi.RelationProperty); ok && raw.TruncatedRelations[key] {
+			prop, truncated = c.completeRelation(ctx, page.ID, relationProp)
+		}
+		value := c.extractPropertyValue(ctx, prop, config.Timezone)
+		if titles, ok := value.([]string); ok && truncated {
+			value = append(titles, truncatedMarker)
+		}
+		schedule.Properties[key] = value
+
+		switch p := prop.(type) {
+		case *notionapi.TitleProperty:
+			schedule.RichText[key] = toRichText(p.Title)
+		case *notionapi.RichTextProperty:
+			schedule.RichText[key] = toRichText(p.RichText)
+		}
+
+		if peopleProp, ok := prop.(*notionapi.PeopleProperty); ok {
+			if schedule.PeopleIDs == nil {
+				schedule.PeopleIDs = make(map[string][]string)
+			}
+			for _, person := range peopleProp.People {
+				schedule.PeopleIDs[key] = append(schedule.PeopleIDs[key], person.ID.String())
+			}
+		}
 	}
 
 	return schedule, nil
 }
 
+// resolveRecurrenceFilter returns a filter matching pages with a recurrence rule,
+// or nil when the target database has no recurrence property
+func (c *Client) resolveRecurrenceFilter(ctx context.Context, config *model.ReminderConfig) (notionapi.Filter, error) {
+	db, err := c.getDatabase(ctx, config.TargetDatabaseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch database schema %s: %w", config.TargetDatabaseID, err)
+	}
+
+	for _, name := range []string{config.RecurrencePropertyName, "Recurrence"} {
+		prop, ok := db.Properties[name]
+		if !ok {
+			continue
+		}
+		switch prop.GetType() {
+		case notionapi.PropertyConfigTypeRichText:
+			return &notionapi.PropertyFilter{
+				Property: name,
+				RichText: &notionapi.TextFilterCondition{IsNotEmpty: true},
+			}, nil
+		case notionapi.PropertyConfigTypeSelect:
+			return &notionapi.PropertyFilter{
+				Property: name,
+				Select:   &notionapi.SelectFilterCondition{IsNotEmpty: true},
+			}, nil
+		default:
+			logging.FromContext(ctx).Warn("recurrence property must be rich_text or select", "property", name, "type", prop.GetType())
+		}
+	}
+
+	return nil, nil
+}
+
+// isDateOnly reports whether a raw Notion date start has no time component.
+// notionapi parses both "2006-01-02" and "2006-01-02T00:00:00Z" as midnight UTC,
+// so the raw value is the only way to tell them apart.
+func isDateOnly(start string) bool {
+	return start != "" && !strings.Contains(start, "T")
+}
+
 func getScheduleRichTextProperty(page notionapi.Page, names ...string) *notionapi.RichTextProperty {
 	for _, name := range names {
 		if prop, ok := page.Properties[name].(*notionapi.RichTextProperty); ok {
@@ -128,6 +226,15 @@ func getScheduleMultiSelectProperty(page notionapi.Page, names ...string) *notio
 	return nil
 }
 
+func getScheduleSelectProperty(page notionapi.Page, names ...string) *notionapi.SelectProperty {
+	for _, name := range names {
+		if prop, ok := page.Properties[name].(*notionapi.SelectProperty); ok {
+			return prop
+		}
+	}
+	return nil
+}
+
 func getScheduleFormulaProperty(page notionapi.Page, names ...string) *notionapi.FormulaProperty {
 	for _, name := range names {
 		if prop, ok := page.Properties[name].(*notionapi.FormulaProperty); ok {
@@ -152,46 +259,3 @@ func formatFormulaValue(formula notionapi.Formula) string {
 	}
 	return ""
 }
-
-// extractPropertyValue extracts the value from a Notion property
-func extractPropertyValue(prop notionapi.Property) interface{} {
-	switch p := prop.(type) {
-	case *notionapi.TitleProperty:
-		if len(p.Title) > 0 {
-			return p.Title[0].PlainText
-		}
-	case *notionapi.RichTextProperty:
-		if len(p.RichText) > 0 {
-			return p.RichText[0].PlainText
-		}
-	case *notionapi.NumberProperty:
-		return p.Number
-	case *notionapi.SelectProperty:
-		if p.Select.Name != "" {
-			return p.Select.Name
-		}
-	case *notionapi.MultiSelectProperty:
-		var values []string
-		for _, opt := range p.MultiSelect {
-			values = append(values, opt.Name)
-		}
-		return values
-	case *notionapi.DateProperty:
-		if p.Date != nil && p.Date.Start != nil {
-			return time.Time(*p.Date.Start).Format("2006-01-02")
-		}
-	case *notionapi.PeopleProperty:
-		var names []string
-		for _, person := range p.People {
-			if person.Name != "" {
-				names = append(names, person.Name)
-			}
-		}
-		return names
-	case *notionapi.CheckboxProperty:
-		return p.Checkbox
-	case *notionapi.URLProperty:
-		return string(p.URL)
-	}
-	return nil
-}
@@ -0,0 +1,96 @@
+package notion
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jomei/notionapi"
+)
+
+func TestParseQueryResultKeepsRawDateStarts(t *testing.T) {
+	data := []byte(`{
+		"object": "list",
+		"has_more": false,
+		"results": [
+			{"object": "page", "id": "page-1", "properties": {
+				"期限日": {"id": "a", "type": "date", "date": {"start": "2026-10-20"}}}},
+			{"object": "page", "id": "page-2", "properties": {
+				"期限日": {"id": "a", "type": "date", "date": {"start": "2026-10-20T00:00:00Z"}},
+				"タイトル": {"id": "title", "type": "title", "title": []}}}
+		]
+	}`)
+
+	result, err := parseQueryResult(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Results) != 2 {
+		t.Fatalf("got %d results", len(result.Results))
+	}
+
+	// Both parse to midnight UTC; only the raw value tells a date from a datetime
+	dateOnly := result.Raw[notionapi.ObjectID("page-1")].DateStarts["期限日"]
+	dateTime := result.Raw[notionapi.ObjectID("page-2")].DateStarts["期限日"]
+	if !isDateOnly(dateOnly) {
+		t.Errorf("%q: expected date-only", dateOnly)
+	}
+	if isDateOnly(dateTime) {
+		t.Errorf("%q: expected a datetime", dateTime)
+	}
+	if isDateOnly("") {
+		t.Error("an empty start is not date-only")
+	}
+}
+
+func TestCompleteRelationFetchesEveryPage(t *testing.T) {
+	data := []byte(`{
+		"object": "list",
+		"has_more": false,
+		"results": [
+			{"object": "page", "id": "page-1", "properties": {
+				"担当": {"id": "rel", "type": "relation", "relation": [{"id": "r1"}], "has_more": true},
+				"関連": {"id": "other", "type": "relation", "relation": [], "has_more": false}}}
+		]
+	}`)
+	result, err := parseQueryResult(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	raw := result.Raw[notionapi.ObjectID("page-1")]
+	if !raw.TruncatedRelations["担当"] || raw.TruncatedRelations["関連"] {
+		t.Fatalf("unexpected truncated relations: %v", raw.TruncatedRelations)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pages/page-1/properties/rel" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.URL.Query().Get("start_cursor") == "" {
+			fmt.Fprint(w, `{"object":"list","results":[{"type":"relation","relation":{"id":"r1"}},{"type":"relation","relation":{"id":"r2"}}],"has_more":true,"next_cursor":"c2"}`)
+			return
+		}
+		fmt.Fprint(w, `{"object":"list","results":[{"type":"relation","relation":{"id":"r3"}}],"has_more":false,"next_cursor":null}`)
+	}))
+	defer server.Close()
+
+	c := &Client{httpClient: server.Client(), baseURL: server.URL + "/"}
+	prop := &notionapi.RelationProperty{ID: "rel", Type: notionapi.PropertyTypeRelation, Relation: []notionapi.Relation{{ID: "r1"}}}
+
+	complete, truncated := c.completeRelation(context.Background(), "page-1", prop)
+	if truncated {
+		t.Fatal("expected the full relation")
+	}
+	relations := complete.(*notionapi.RelationProperty).Relation
+	if len(relations) != 3 || relations[2].ID != "r3" {
+		t.Errorf("got %v, want r1, r2, r3", relations)
+	}
+
+	server.Close()
+	if _, truncated := c.completeRelation(context.Background(), "page-1", prop); !truncated {
+		t.Error("a failed fetch should report the relation as truncated")
+	}
+}
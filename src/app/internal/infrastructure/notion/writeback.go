@@ -0,0 +1,194 @@
+package notion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"schedule-reminder/internal/domain/model"
+	"schedule-reminder/internal/infrastructure/logging"
+	"strings"
+	"time"
+
+	"github.com/jomei/notionapi"
+)
+
+// AdvanceSchedule moves a recurring schedule's due date to the next occurrence
+// and resets its completion checkbox
+func (c *Client) AdvanceSchedule(ctx context.Context, config *model.ReminderConfig, schedule *model.Schedule, next time.Time) error {
+	properties := notionapi.Properties{
+		config.DatePropertyName: datePropertyValue(next, schedule.AllDay),
+	}
+	if schedule.Completed {
+		properties[config.CompletionPropertyName] = &notionapi.CheckboxProperty{
+			Type:     notionapi.PropertyTypeCheckbox,
+			Checkbox: false,
+		}
+	}
+
+	_, err := c.client.Page.Update(ctx, notionapi.PageID(schedule.ID), &notionapi.PageUpdateRequest{
+		Properties: properties,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update schedule %s: %w", schedule.ID, err)
+	}
+
+	logging.FromContext(ctx).Info("advanced schedule due date",
+		"property", config.DatePropertyName,
+		"from", formatNotionDate(schedule.DueDate, schedule.AllDay),
+		"to", formatNotionDate(next, schedule.AllDay),
+		"completionReset", schedule.Completed)
+
+	return nil
+}
+
+// datePropertyValue builds a date property value, keeping date-only values date-only
+func datePropertyValue(t time.Time, allDay bool) notionapi.Property {
+	if allDay {
+		return dateOnlyProperty{Start: formatNotionDate(t, true)}
+	}
+	start := notionapi.Date(t)
+	return &notionapi.DateProperty{
+		Type: notionapi.PropertyTypeDate,
+		Date: &notionapi.DateObject{Start: &start},
+	}
+}
+
+func formatNotionDate(t time.Time, allDay bool) string {
+	if allDay {
+		return t.Format("2006-01-02")
+	}
+	return t.Format(time.RFC3339)
+}
+
+// dateOnlyProperty is a date property value without a time component.
+// notionapi.Date always marshals as RFC 3339, which would turn a date into a datetime.
+type dateOnlyProperty struct {
+	Start string
+}
+
+func (p dateOnlyProperty) GetID() string {
+	return ""
+}
+
+func (p dateOnlyProperty) GetType() notionapi.PropertyType {
+	return notionapi.PropertyTypeDate
+}
+
+func (p dateOnlyProperty) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"date": map[string]string{"start": p.Start},
+	})
+}
+
+// RecordReminders stamps the last reminder time on a schedule page and appends
+// an entry per sent reminder to its reminder history (multi-select or rich text)
+func (c *Client) RecordReminders(ctx context.Context, config *model.ReminderConfig, schedule *model.Schedule, sent []model.SentReminder) error {
+	// Re-read the page so history appended by earlier runs is kept
+	page, err := c.client.Page.Get(ctx, notionapi.PageID(schedule.ID))
+	if err != nil {
+		return fmt.Errorf("failed to fetch schedule %s: %w", schedule.ID, err)
+	}
+
+	properties := reminderProperties(config, page.Properties, sent)
+	if len(properties) == 0 {
+		return fmt.Errorf("schedule database has neither %q (date) nor %q (multi_select or rich_text)",
+			config.LastRemindedPropertyName, config.HistoryPropertyName)
+	}
+
+	if _, err := c.client.Page.Update(ctx, notionapi.PageID(schedule.ID), &notionapi.PageUpdateRequest{
+		Properties: properties,
+	}); err != nil {
+		return fmt.Errorf("failed to record reminder on schedule %s: %w", schedule.ID, err)
+	}
+
+	logging.FromContext(ctx).Debug("recorded reminder history", "reminders", len(sent))
+	return nil
+}
+
+// reminderProperties builds the updates that record sent reminders on a page
+// with the given properties. It is empty when the page has neither property.
+func reminderProperties(config *model.ReminderConfig, current notionapi.Properties, sent []model.SentReminder) notionapi.Properties {
+	properties := notionapi.Properties{}
+	if len(sent) == 0 {
+		return properties
+	}
+
+	if _, ok := current[config.LastRemindedPropertyName].(*notionapi.DateProperty); ok {
+		last := sent[0].SentAt
+		for _, reminder := range sent[1:] {
+			if reminder.SentAt.After(last) {
+				last = reminder.SentAt
+			}
+		}
+		properties[config.LastRemindedPropertyName] = datePropertyValue(last.In(config.Timezone), false)
+	}
+
+	switch prop := current[config.HistoryPropertyName].(type) {
+	case *notionapi.MultiSelectProperty:
+		// Option names cannot contain commas
+		entries := make([]string, 0, len(sent))
+		for _, reminder := range sent {
+			entries = append(entries, fmt.Sprintf("%s %s", reminder.SentAt.In(config.Timezone).Format("2006-01-02"), reminder.Timing))
+		}
+		properties[config.HistoryPropertyName] = &notionapi.MultiSelectProperty{
+			Type:        notionapi.PropertyTypeMultiSelect,
+			MultiSelect: mergeHistoryOptions(prop.MultiSelect, entries),
+		}
+	case *notionapi.RichTextProperty:
+		history := plainText(prop.RichText)
+		for _, reminder := range sent {
+			entry := fmt.Sprintf("%s %s (%s)", reminder.SentAt.In(config.Timezone).Format("2006-01-02 15:04"), reminder.Timing, reminder.Channel)
+			history = appendHistoryLine(history, entry)
+		}
+		properties[config.HistoryPropertyName] = &notionapi.RichTextProperty{
+			Type: notionapi.PropertyTypeRichText,
+			RichText: []notionapi.RichText{{
+				Type: notionapi.ObjectTypeText,
+				Text: &notionapi.Text{Content: history},
+			}},
+		}
+	}
+	return properties
+}
+
+// mergeHistoryOptions appends entries to multi-select options, keeping each
+// name once and moving repeated names to the end
+func mergeHistoryOptions(options []notionapi.Option, entries []string) []notionapi.Option {
+	added := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		added[entry] = true
+	}
+
+	merged := make([]notionapi.Option, 0, len(options)+len(entries))
+	for _, option := range options {
+		if !added[option.Name] {
+			merged = append(merged, notionapi.Option{Name: option.Name})
+		}
+	}
+	for _, entry := range entries {
+		if added[entry] {
+			merged = append(merged, notionapi.Option{Name: entry})
+			delete(added, entry)
+		}
+	}
+	return merged
+}
+
+// maxRichTextLength is Notion's limit for a single rich text object
+const maxRichTextLength = 2000
+
+// appendHistoryLine appends a line, dropping the oldest lines to stay within Notion's limit
+func appendHistoryLine(history, entry string) string {
+	lines := []string{}
+	if history != "" {
+		lines = strings.Split(history, "\n")
+	}
+	lines = append(lines, entry)
+
+	result := strings.Join(lines, "\n")
+	for len([]rune(result)) > maxRichTextLength && len(lines) > 1 {
+		lines = lines[1:]
+		result = strings.Join(lines, "\n")
+	}
+	return result
+}
@@ -0,0 +1,105 @@
+package notion
+
+import (
+	"reflect"
+	"schedule-reminder/internal/domain/model"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jomei/notionapi"
+)
+
+func TestAppendHistoryLine(t *testing.T) {
+	long := strings.Repeat("あ", 999)
+	shorter := strings.Repeat("あ", 998) // Leaves room for a one-character line
+
+	tests := []struct {
+		name    string
+		history string
+		entry   string
+		want    string
+	}{
+		{"empty history", "", "2026-03-09 09:00 当日 (Slack)", "2026-03-09 09:00 当日 (Slack)"},
+		{"appends a line", "2026-03-06 09:00 3日前 (Slack)", "2026-03-09 09:00 当日 (Slack)",
+			"2026-03-06 09:00 3日前 (Slack)\n2026-03-09 09:00 当日 (Slack)"},
+		{"exactly at the limit", long + "\n" + shorter, "x", long + "\n" + shorter + "\nx"},
+		{"drops the oldest lines", "old\n" + long + "\n" + long, "new", long + "\nnew"},
+		{"keeps an entry longer than the limit", "old", strings.Repeat("a", 2001), strings.Repeat("a", 2001)},
+	}
+
+	for _, tt := range tests {
+		if got := appendHistoryLine(tt.history, tt.entry); got != tt.want {
+			t.Errorf("%s: got %d characters %q..., want %d characters", tt.name, len([]rune(got)), truncate(got), len([]rune(tt.want)))
+		}
+	}
+}
+
+func TestMergeHistoryOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []string
+		entries []string
+		want    []string
+	}{
+		{"no options", nil, []string{"2026-03-09 当日"}, []string{"2026-03-09 当日"}},
+		{"appends in order", []string{"2026-03-06 3日前"}, []string{"2026-03-09 当日", "2026-03-09 期限超過"},
+			[]string{"2026-03-06 3日前", "2026-03-09 当日", "2026-03-09 期限超過"}},
+		{"moves an existing entry to the end", []string{"2026-03-09 当日", "2026-03-06 3日前"}, []string{"2026-03-09 当日"},
+			[]string{"2026-03-06 3日前", "2026-03-09 当日"}},
+		{"one entry per channel", nil, []string{"2026-03-09 当日", "2026-03-09 当日"}, []string{"2026-03-09 当日"}},
+	}
+
+	for _, tt := range tests {
+		var options []notionapi.Option
+		for _, name := range tt.options {
+			options = append(options, notionapi.Option{ID: notionapi.PropertyID("id-" + name), Name: name})
+		}
+		var got []string
+		for _, option := range mergeHistoryOptions(options, tt.entries) {
+			got = append(got, option.Name)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestReminderPropertiesBatchesSentReminders(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	config := &model.ReminderConfig{Timezone: tokyo, LastRemindedPropertyName: "最終リマインド", HistoryPropertyName: "リマインド履歴"}
+	sentAt := time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+	sent := []model.SentReminder{
+		{Timing: "当日", Channel: "Slack", SentAt: sentAt},
+		{Timing: "当日", Channel: "LINE", SentAt: sentAt.Add(time.Second)},
+	}
+	current := notionapi.Properties{
+		"最終リマインド": &notionapi.DateProperty{Type: notionapi.PropertyTypeDate},
+		"リマインド履歴": &notionapi.RichTextProperty{Type: notionapi.PropertyTypeRichText, RichText: []notionapi.RichText{
+			{PlainText: "2026-03-06 09:00 3日前 (Slack)"},
+		}},
+	}
+
+	properties := reminderProperties(config, current, sent)
+	date, ok := properties["最終リマインド"].(*notionapi.DateProperty)
+	if !ok || !time.Time(*date.Date.Start).Equal(sentAt.Add(time.Second)) {
+		t.Errorf("got last reminded %+v, want the latest send", properties["最終リマインド"])
+	}
+	history := properties["リマインド履歴"].(*notionapi.RichTextProperty).RichText[0].Text.Content
+	want := "2026-03-06 09:00 3日前 (Slack)\n2026-03-09 09:00 当日 (Slack)\n2026-03-09 09:00 当日 (LINE)"
+	if history != want {
+		t.Errorf("got history %q, want %q", history, want)
+	}
+
+	// Neither property exists: nothing to update, which the caller reports
+	if properties := reminderProperties(config, notionapi.Properties{}, sent); len(properties) != 0 {
+		t.Errorf("got %v, want no properties", properties)
+	}
+}
+
+func truncate(s string) string {
+	if runes := []rune(s); len(runes) > 20 {
+		return string(runes[:20])
+	}
+	return s
+}
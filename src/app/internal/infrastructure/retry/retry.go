@@ -0,0 +1,51 @@
+// Package retry holds the backoff helpers shared by the Notion client and the notifiers
+package retry
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Backoff returns an exponential delay for the attempt, starting at base and
+// capped at max, with jitter in its upper half so concurrent callers spread out
+func Backoff(attempt int, base, max time.Duration) time.Duration {
+	delay := base << (attempt - 1)
+	if delay <= 0 || delay > max {
+		delay = max
+	}
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// ParseRetryAfter parses a Retry-After header given in seconds or as an HTTP date
+func ParseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds >= 0 {
+		return time.Duration(seconds * float64(time.Second)), true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := time.Until(date); delay > 0 {
+			return delay, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// Sleep waits for delay and reports whether it elapsed before ctx was done
+func Sleep(ctx context.Context, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,35 @@
+package retry
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	if d, ok := ParseRetryAfter("2"); !ok || d != 2*time.Second {
+		t.Fatalf("got %s, %v", d, ok)
+	}
+	if d, ok := ParseRetryAfter("0.5"); !ok || d != 500*time.Millisecond {
+		t.Fatalf("got %s, %v", d, ok)
+	}
+	if _, ok := ParseRetryAfter("soon"); ok {
+		t.Fatal("expected an invalid value to be rejected")
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	for attempt, want := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 10: time.Second} {
+		if got := Backoff(attempt, 100*time.Millisecond, time.Second); got < want/2 || got > want {
+			t.Fatalf("attempt %d: got %s, want between %s and %s", attempt, got, want/2, want)
+		}
+	}
+}
+
+func TestSleepCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if Sleep(ctx, time.Minute) {
+		t.Fatal("expected a canceled context to end the sleep")
+	}
+}
@@ -0,0 +1,87 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// serviceName is the default OpenTelemetry service name, overridable with OTEL_SERVICE_NAME
+const serviceName = "schedule-reminder"
+
+// Attribute keys set on spans
+const (
+	KeyConfigID   = attribute.Key("reminder.config.id")
+	KeyConfigName = attribute.Key("reminder.config.name")
+	KeyTiming     = attribute.Key("reminder.timing")
+	KeyChannel    = attribute.Key("reminder.channel")
+)
+
+// Provider flushes spans of the configured exporter
+type Provider struct {
+	provider *sdktrace.TracerProvider // nil when tracing is disabled
+}
+
+// Setup installs the global tracer provider. Spans are exported via OTLP/HTTP
+// when OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT is set,
+// e.g. to a local collector or to the collector of the ADOT Lambda layer which
+// forwards them to X-Ray. Otherwise tracing is a no-op.
+func Setup(ctx context.Context) (*Provider, error) {
+	if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") == "" && os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") == "" {
+		return &Provider{}, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName(serviceName)),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tracing resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return &Provider{provider: provider}, nil
+}
+
+// Flush exports buffered spans. Lambda freezes the process between
+// invocations, so it must be called before the handler returns.
+func (p *Provider) Flush(ctx context.Context) error {
+	if p.provider == nil {
+		return nil
+	}
+	return p.provider.ForceFlush(ctx)
+}
+
+// Start starts a span with the global tracer provider
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(serviceName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records err on the span, if any, and ends it
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
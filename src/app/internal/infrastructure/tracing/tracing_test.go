@@ -0,0 +1,55 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestStartAndEnd(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(previous)
+
+	ctx, parent := Start(context.Background(), "ProcessReminders")
+	_, child := Start(ctx, "Notifier.Send", KeyChannel.String("Slack"))
+	End(child, errors.New("slack webhook returned status 500"))
+	End(parent, nil)
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	send, process := spans[0], spans[1]
+	if send.Parent().SpanID() != process.SpanContext().SpanID() {
+		t.Error("Notifier.Send is not a child of ProcessReminders")
+	}
+	if send.Status().Code != codes.Error || len(send.Events()) != 1 {
+		t.Errorf("error not recorded on the failed span: %+v", send.Status())
+	}
+	if process.Status().Code != codes.Unset {
+		t.Errorf("got status %v on the successful span", process.Status().Code)
+	}
+	if got := send.Attributes(); len(got) != 1 || got[0] != KeyChannel.String("Slack") {
+		t.Errorf("got attributes %v", got)
+	}
+}
+
+func TestSetupWithoutEndpointIsNoop(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+
+	provider, err := Setup(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := provider.Flush(context.Background()); err != nil {
+		t.Fatalf("unexpected flush error: %v", err)
+	}
+}
@@ -1,53 +1,322 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-lambda-go/lambdacontext"
+	"go.opentelemetry.io/otel/attribute"
 
+	"schedule-reminder/internal/domain/model"
 	"schedule-reminder/internal/domain/service"
 	awsinfra "schedule-reminder/internal/infrastructure/aws"
+	"schedule-reminder/internal/infrastructure/ical"
+	"schedule-reminder/internal/infrastructure/logging"
+	"schedule-reminder/internal/infrastructure/metrics"
+	"schedule-reminder/internal/infrastructure/notifier"
 	"schedule-reminder/internal/infrastructure/notion"
+	"schedule-reminder/internal/infrastructure/tracing"
 )
 
+const (
+	// metricsNamespace is the CloudWatch namespace of the EMF metrics
+	metricsNamespace = "ScheduleReminder"
+
+	// defaultUpcomingDays is how many days the upcoming action lists without "days"
+	defaultUpcomingDays = 14
+
+	// defaultCalendarDays is how many days the calendar action exports without "days"
+	defaultCalendarDays = 90
+
+	// defaultCalendarKey is the object key of the calendar feed without CALENDAR_KEY
+	defaultCalendarKey = "reminders.ics"
+
+	// calendarName is the name calendar apps show for the feed
+	calendarName = "Notionリマインダー"
+
+	// defaultCatchUpMaxDays is how many missed days are caught up without CATCH_UP_MAX_DAYS
+	defaultCatchUpMaxDays = 3
+
+	// traceFlushTimeout bounds exporting the spans of an invocation
+	traceFlushTimeout = 5 * time.Second
+)
+
+// tracer exports the spans of each invocation; set up once per cold start
+var tracer = &tracing.Provider{}
+
+// Event is the Lambda input. The daily schedule sends an empty event, which
+// processes every configuration for today.
+type Event struct {
+	// Action selects what to run: "" or "remind" processes reminders,
+	// "redrive" replays notifications from the dead-letter queue and
+	// "upcoming" lists the reminders of the coming days without sending them and
+	// "calendar" publishes the due dates and reminders as an iCalendar feed to S3
+	Action string `json:"action"`
+
+	// Days is how many days "upcoming" lists (default 14) and "calendar" exports
+	// (default 90), starting today
+	Days int `json:"days"`
+
+	// DryRun renders notifications without sending them; DRY_RUN=1 forces it for every invocation
+	DryRun bool `json:"dryRun"`
+
+	// The fields below only apply to reminders, upcoming and calendar
+	Date            string   `json:"date"`            // Process as if today were this date (YYYY-MM-DD)
+	ConfigIDs       []string `json:"configIds"`       // Only process these configurations
+	ScheduleIDs     []string `json:"scheduleIds"`     // Only process these schedules
+	ChannelOverride string   `json:"channelOverride"` // Send to this channel instead of the configured ones
+}
+
+// CalendarResponse is the Lambda response of the calendar action
+type CalendarResponse struct {
+	Bucket string                  `json:"bucket"`
+	Key    string                  `json:"key"`
+	Events int                     `json:"events"`
+	DryRun bool                    `json:"dryRun,omitempty"` // The feed was built but not uploaded
+	Issues []service.FailureReport `json:"issues,omitempty"`
+}
+
+// reminderOptions turns the overrides of an event into service options
+func (e Event) reminderOptions() ([]service.Option, error) {
+	var opts []service.Option
+	if e.Date != "" {
+		date, err := time.Parse("2006-01-02", e.Date)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q: expected YYYY-MM-DD", e.Date)
+		}
+		opts = append(opts, service.WithDate(date))
+	}
+	if len(e.ConfigIDs) > 0 || len(e.ScheduleIDs) > 0 {
+		opts = append(opts, service.WithScope(e.ConfigIDs, e.ScheduleIDs))
+	}
+	if e.ChannelOverride != "" {
+		if !model.IsNotificationChannel(e.ChannelOverride) {
+			return nil, fmt.Errorf("unsupported channelOverride %q", e.ChannelOverride)
+		}
+		opts = append(opts, service.WithChannelOverride(e.ChannelOverride))
+	}
+	return opts, nil
+}
+
 // handler is the Lambda function handler for scheduled events
-func handler(ctx context.Context) error {
-	fmt.Println("=== Schedule Reminder Lambda Started ===")
+func handler(ctx context.Context, event Event) (response interface{}, err error) {
+	// Every log line of this invocation carries the request ID
+	log := logging.FromContext(ctx)
+	if lc, ok := lambdacontext.FromContext(ctx); ok {
+		ctx, log = logging.With(ctx, logging.KeyRequestID, lc.AwsRequestID)
+	}
+	dryRun := event.DryRun || logging.EnvFlag("DRY_RUN")
+	log.Info("schedule reminder started", "action", event.Action, "dryRun", dryRun,
+		"date", event.Date, "configIds", event.ConfigIDs, "scheduleIds", event.ScheduleIDs, "channelOverride", event.ChannelOverride)
+
+	ctx, span := tracing.Start(ctx, "ScheduleReminder.Invoke", attribute.String("reminder.action", event.Action))
+	defer func() {
+		tracing.End(span, err)
+		// Export even when the invocation ran out of time
+		flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), traceFlushTimeout)
+		defer cancel()
+		if flushErr := tracer.Flush(flushCtx); flushErr != nil {
+			log.Warn("failed to export traces", logging.KeyError, flushErr)
+		}
+	}()
+
+	// Reject malformed events before touching any external service
+	overrides, err := event.reminderOptions()
+	if err != nil {
+		return nil, err
+	}
+	if event.Action == "redrive" && len(overrides) > 0 {
+		return nil, fmt.Errorf("date, configIds, scheduleIds and channelOverride do not apply to redrive")
+	}
 
 	// Create SSM client to retrieve parameters from Parameter Store
 	ssmClient, err := awsinfra.NewSSMClient(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to create SSM client: %w", err)
+		return nil, fmt.Errorf("failed to create SSM client: %w", err)
 	}
 
 	// Get configuration from Parameter Store
 	notionAPIKey, err := ssmClient.GetParameterWithFallback(ctx, "NOTION_API_KEY")
 	if err != nil {
-		return fmt.Errorf("failed to get NOTION_API_KEY: %w", err)
+		return nil, fmt.Errorf("failed to get NOTION_API_KEY: %w", err)
 	}
 
 	masterDBID, err := ssmClient.GetParameterWithFallback(ctx, "REMINDER_CONFIG_DB_ID")
 	if err != nil {
-		return fmt.Errorf("failed to get REMINDER_CONFIG_DB_ID: %w", err)
+		return nil, fmt.Errorf("failed to get REMINDER_CONFIG_DB_ID: %w", err)
 	}
 
 	// Create Notion client
 	notionClient := notion.NewClient(notionAPIKey)
 
 	// Create reminder service
-	reminderService := service.NewReminderService(notionClient, masterDBID)
+	opts := []service.Option{service.WithMetrics(metrics.NewEMFSink(os.Stdout, metricsNamespace))}
+	if dryRun {
+		opts = append(opts, service.WithDryRun())
+	}
+	opts = append(opts, overrides...)
+	if days := catchUpMaxDays(log); days > 0 {
+		opts = append(opts, service.WithCatchUp(awsinfra.NewRunStateStore(ssmClient), days))
+	}
+	if queueURL := strings.TrimSpace(os.Getenv("DEAD_LETTER_QUEUE_URL")); queueURL != "" {
+		deadLetters, err := awsinfra.NewDeadLetterQueue(ctx, queueURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create dead-letter queue client: %w", err)
+		}
+		opts = append(opts, service.WithDeadLetterQueue(deadLetters))
+	}
+	// Operator alerts are optional; without the parameter failures are only logged
+	if alertURL, err := ssmClient.GetParameterWithFallback(ctx, "OPERATOR_ALERT_WEBHOOK_URL"); err == nil && strings.TrimSpace(alertURL) != "" {
+		alerts, err := notifier.CreateWebhookNotifier(strings.TrimSpace(alertURL))
+		if err != nil {
+			return nil, fmt.Errorf("invalid OPERATOR_ALERT_WEBHOOK_URL: %w", err)
+		}
+		opts = append(opts, service.WithOperatorAlerts(alerts))
+	} else {
+		log.Info("operator alerts disabled: OPERATOR_ALERT_WEBHOOK_URL is not set")
+	}
+	reminderService := service.NewReminderService(notionClient, masterDBID, opts...)
+
+	switch event.Action {
+	case "", "remind":
+		// Process reminders
+		report, err := reminderService.ProcessReminders(ctx)
+		if err != nil {
+			log.Error("failed to process reminders", logging.KeyError, err)
+			return nil, err
+		}
+		log.Info("run report", "report", report)
+		if dryRun {
+			log.Info("schedule reminder completed (dry run)")
+			return report, nil
+		}
+
+		// Fail the invocation so that Lambda error alarms fire
+		threshold := failureThreshold(log)
+		if failures := report.Failures(); failures > threshold {
+			return report, fmt.Errorf("%d failures exceed the threshold of %d (configs failed: %d, notifications failed: %d, skipped: %d)",
+				failures, threshold, report.ConfigsFailed, report.NotificationsFailed, report.NotificationsSkipped)
+		}
+		log.Info("schedule reminder completed")
+		return report, nil
+	case "upcoming":
+		days := event.Days
+		if days == 0 {
+			days = defaultUpcomingDays
+		}
+		report, err := reminderService.Upcoming(ctx, days)
+		if err != nil {
+			log.Error("failed to list upcoming reminders", logging.KeyError, err)
+			return nil, err
+		}
+		log.Info("schedule reminder completed")
+		return report, nil
+	case "calendar":
+		response, err := publishCalendar(ctx, reminderService, event.Days, dryRun)
+		if err != nil {
+			log.Error("failed to publish calendar", logging.KeyError, err)
+			return nil, err
+		}
+		log.Info("schedule reminder completed")
+		return response, nil
+	case "redrive":
+		result, err := reminderService.Redrive(ctx)
+		if err != nil {
+			log.Error("failed to redrive notifications", logging.KeyError, err)
+			return result, err
+		}
+		log.Info("schedule reminder completed")
+		return result, nil
+	default:
+		return nil, fmt.Errorf("unknown action %q", event.Action)
+	}
+}
+
+// publishCalendar uploads the iCalendar feed of the coming days to CALENDAR_BUCKET
+func publishCalendar(ctx context.Context, reminderService *service.ReminderService, days int, dryRun bool) (*CalendarResponse, error) {
+	bucket := strings.TrimSpace(os.Getenv("CALENDAR_BUCKET"))
+	if bucket == "" {
+		return nil, fmt.Errorf("CALENDAR_BUCKET is not set")
+	}
+	key := strings.TrimSpace(os.Getenv("CALENDAR_KEY"))
+	if key == "" {
+		key = defaultCalendarKey
+	}
+	if days == 0 {
+		days = defaultCalendarDays
+	}
+
+	feed, err := reminderService.CalendarEvents(ctx, days)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := ical.Encode(&buf, calendarName, feed.Events, time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to encode calendar: %w", err)
+	}
 
-	// Process reminders
-	if err := reminderService.ProcessReminders(ctx); err != nil {
-		fmt.Printf("Error processing reminders: %v\n", err)
-		return err
+	response := &CalendarResponse{Bucket: bucket, Key: key, Events: len(feed.Events), DryRun: dryRun, Issues: feed.Issues}
+	if dryRun {
+		logging.FromContext(ctx).Info("dry run: calendar not uploaded", "bucket", bucket, "key", key, "bytes", buf.Len())
+		return response, nil
 	}
 
-	fmt.Println("=== Schedule Reminder Lambda Completed ===")
-	return nil
+	calendarBucket, err := awsinfra.NewCalendarBucket(ctx, bucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 client: %w", err)
+	}
+	if err := calendarBucket.Put(ctx, key, buf.Bytes()); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("published calendar", "bucket", bucket, "key", key, "events", len(feed.Events))
+	return response, nil
+}
+
+// failureThreshold returns how many failures a run tolerates before the
+// invocation fails, from FAILURE_THRESHOLD (default 0)
+func failureThreshold(log *slog.Logger) int {
+	value := strings.TrimSpace(os.Getenv("FAILURE_THRESHOLD"))
+	if value == "" {
+		return 0
+	}
+	threshold, err := strconv.Atoi(value)
+	if err != nil || threshold < 0 {
+		log.Warn("invalid FAILURE_THRESHOLD, using 0", "value", value)
+		return 0
+	}
+	return threshold
+}
+
+// catchUpMaxDays returns how many days before today missed reminders are sent
+// late, from CATCH_UP_MAX_DAYS (0 disables catching up)
+func catchUpMaxDays(log *slog.Logger) int {
+	value := strings.TrimSpace(os.Getenv("CATCH_UP_MAX_DAYS"))
+	if value == "" {
+		return defaultCatchUpMaxDays
+	}
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 0 {
+		log.Warn("invalid CATCH_UP_MAX_DAYS, using the default", "value", value, "default", defaultCatchUpMaxDays)
+		return defaultCatchUpMaxDays
+	}
+	return days
 }
 
 func main() {
+	slog.SetDefault(logging.New(os.Stdout))
+
+	provider, err := tracing.Setup(context.Background())
+	if err != nil {
+		slog.Warn("tracing disabled", logging.KeyError, err)
+	} else {
+		tracer = provider
+	}
 	lambda.Start(handler)
 }
@@ -7,6 +7,13 @@ if [ -z "${localstack_ip}" ]; then
   exit 1
 fi
 
+# 第1引数でイベントファイルを指定できる（例: events/redrive.json）
+event_args=()
+if [ -n "${1:-}" ]; then
+  event_args=(--event "$1")
+fi
+
 sam local invoke ScheduleReminderFunction \
   --config-env local \
-  --add-host "localstack:${localstack_ip}"
+  --add-host "localstack:${localstack_ip}" \
+  ${event_args[@]+"${event_args[@]}"}